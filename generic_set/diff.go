@@ -0,0 +1,35 @@
+package generic_set
+
+import (
+	"fmt"
+)
+
+// DiffResult describes how one set changed into another. Please use Diff to create one
+type DiffResult[K comparable] struct {
+	// Added holds the items of the new set that were not in the old set
+	Added Immutable[K]
+	// Removed holds the items of the old set that are not in the new set
+	Removed Immutable[K]
+	// Unchanged holds the items of the old set that are still in the new set
+	Unchanged Immutable[K]
+}
+
+// Diff returns the changes that turn old into new. Each of the sets in the result is created by old or new, so it
+// compares items the way they do
+func Diff[K comparable](old, new Immutable[K]) DiffResult[K] {
+	return DiffResult[K]{
+		Added:     new.Subtract(old),
+		Removed:   old.Subtract(new),
+		Unchanged: old.Intersection(new),
+	}
+}
+
+// HasChanges returns true if any item was added or removed
+func (d DiffResult[K]) HasChanges() bool {
+	return !d.Added.IsEmpty() || !d.Removed.IsEmpty()
+}
+
+// String summarizes the number of items added, removed and unchanged
+func (d DiffResult[K]) String() string {
+	return fmt.Sprintf("%d added, %d removed, %d unchanged", d.Added.Len(), d.Removed.Len(), d.Unchanged.Len())
+}
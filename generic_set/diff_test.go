@@ -0,0 +1,22 @@
+package generic_set
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	old := NewOf[int64](1, 2, 3)
+	updated := NewOf[int64](2, 3, 4, 5)
+
+	d := Diff[int64](old, updated)
+	assert.True(t, NewOf[int64](4, 5).IsEqualTo(d.Added))
+	assert.True(t, NewOf[int64](1).IsEqualTo(d.Removed))
+	assert.True(t, NewOf[int64](2, 3).IsEqualTo(d.Unchanged))
+	assert.True(t, d.HasChanges())
+	assert.Equal(t, "2 added, 1 removed, 2 unchanged", d.String())
+
+	assert.Equal(t, 3, old.Apply(d))
+	assert.True(t, updated.IsEqualTo(old))
+	assert.False(t, Diff[int64](old, updated).HasChanges())
+}
@@ -0,0 +1,330 @@
+package generic_set
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+const (
+	defaultCapacity = 10
+)
+
+// New creates a new set, with a small default capacity
+func New[K comparable]() *T[K] {
+	return NewWithCapacity[K](defaultCapacity)
+}
+
+// NewOf is a convenience method to create a set containing the items you specify
+func NewOf[K comparable](items ...K) *T[K] {
+	ret := NewWithCapacity[K](len(items))
+	ret.AddMany(items...)
+	return ret
+}
+
+// Collect creates a new set containing every item produced by seq
+func Collect[K comparable](seq iter.Seq[K]) *T[K] {
+	ret := New[K]()
+	for v := range seq {
+		ret.Add(v)
+	}
+	return ret
+}
+
+// NewWithCapacity creates a new, empty, set with the provided capacity
+func NewWithCapacity[K comparable](capacity int) *T[K] {
+	return &T[K]{
+		items: make(map[K]bool, capacity),
+	}
+}
+
+// T holds the underlying generic_set type, do not instantiate this yourself,
+// Please use New, NewOf, or NewWithCapacity. The zero value is an empty set ready to use
+type T[K comparable] struct {
+	items map[K]bool
+}
+
+func (c *T[K]) Add(v K) {
+	c.ensureItems()
+	c.items[v] = true
+}
+
+// ensureItems creates the map of a zero-value set before the first item is added
+func (c *T[K]) ensureItems() {
+	if c.items == nil {
+		c.items = make(map[K]bool, defaultCapacity)
+	}
+}
+
+func (c *T[K]) AddMany(v ...K) {
+	for _, s := range v {
+		c.Add(s)
+	}
+}
+
+func (c *T[K]) Remove(v K) {
+	delete(c.items, v)
+}
+
+func (c *T[K]) RemoveMany(v ...K) {
+	for _, s := range v {
+		c.Remove(s)
+	}
+}
+
+func (c *T[K]) Includes(v K) bool {
+	_, ok := c.items[v]
+	return ok
+}
+
+func (c *T[K]) IsEmpty() bool {
+	return c.Len() == 0
+}
+
+func (c *T[K]) Len() int {
+	return len(c.items)
+}
+
+func (c *T[K]) IsEqualTo(o Immutable[K]) (equal bool) {
+	// short-circuit test for speed
+	if c.Len() != o.Len() {
+		return false
+	}
+	equal = true
+	c.EachCancelable(func(v K) NextAction {
+		if !o.Includes(v) {
+			equal = false
+			return Break
+		}
+		return Continue
+	})
+	return
+}
+
+func (c *T[K]) Union(o Immutable[K]) (out Interface[K]) {
+	out = c.Copy()
+	o.Each(func(v K) {
+		out.Add(v)
+	})
+	return
+}
+
+func (c *T[K]) Subtract(o Immutable[K]) (out Interface[K]) {
+	out = NewWithCapacity[K](c.Len())
+	c.Each(func(v K) {
+		if !o.Includes(v) {
+			out.Add(v)
+		}
+	})
+	return
+}
+
+func (c *T[K]) Intersection(o Immutable[K]) (out Interface[K]) {
+	out = NewWithCapacity[K](c.Len())
+	o.Each(func(v K) {
+		if c.Includes(v) {
+			out.Add(v)
+		}
+	})
+	return
+}
+
+func (c *T[K]) SymmetricDifference(o Immutable[K]) (out Interface[K]) {
+	out = c.Subtract(o)
+	o.Each(func(v K) {
+		if !c.Includes(v) {
+			out.Add(v)
+		}
+	})
+	return
+}
+
+func (c *T[K]) UnionWith(o Immutable[K]) (changed int) {
+	c.ensureItems()
+	before := len(c.items)
+	o.Each(func(v K) {
+		c.items[v] = true
+	})
+	return len(c.items) - before
+}
+
+func (c *T[K]) SubtractWith(o Immutable[K]) (changed int) {
+	before := len(c.items)
+	if o.Len() < len(c.items) {
+		o.Each(func(v K) {
+			delete(c.items, v)
+		})
+	} else {
+		// deleting while ranging over a map is safe, even when o is the callee
+		for v := range c.items {
+			if o.Includes(v) {
+				delete(c.items, v)
+			}
+		}
+	}
+	return before - len(c.items)
+}
+
+func (c *T[K]) IntersectWith(o Immutable[K]) (changed int) {
+	before := len(c.items)
+	for v := range c.items {
+		if !o.Includes(v) {
+			delete(c.items, v)
+		}
+	}
+	return before - len(c.items)
+}
+
+func (c *T[K]) SymmetricDifferenceWith(o Immutable[K]) (changed int) {
+	if other, ok := o.(*T[K]); ok && other == c {
+		changed = len(c.items)
+		clear(c.items)
+		return
+	}
+	c.ensureItems()
+	// each item of o is seen once, so toggling it cannot undo an earlier change
+	o.Each(func(v K) {
+		if c.items[v] {
+			delete(c.items, v)
+		} else {
+			c.items[v] = true
+		}
+		changed++
+	})
+	return
+}
+
+func (c *T[K]) Apply(d DiffResult[K]) (changed int) {
+	return c.SubtractWith(d.Removed) + c.UnionWith(d.Added)
+}
+
+func (c *T[K]) IsSubsetOf(o Immutable[K]) bool {
+	// a larger set cannot fit in a smaller one
+	if c.Len() > o.Len() {
+		return false
+	}
+	return c.IntersectionLen(o) == c.Len()
+}
+
+func (c *T[K]) IsProperSubsetOf(o Immutable[K]) bool {
+	return c.Len() < o.Len() && c.IsSubsetOf(o)
+}
+
+func (c *T[K]) IsSupersetOf(o Immutable[K]) bool {
+	if c.Len() < o.Len() {
+		return false
+	}
+	return c.IntersectionLen(o) == o.Len()
+}
+
+func (c *T[K]) IsDisjointFrom(o Immutable[K]) bool {
+	smaller, larger := smallerFirst[K](c, o)
+	disjoint := true
+	smaller.EachCancelable(func(v K) NextAction {
+		if larger.Includes(v) {
+			disjoint = false
+			return Break
+		}
+		return Continue
+	})
+	return disjoint
+}
+
+func (c *T[K]) IntersectionLen(o Immutable[K]) (count int) {
+	smaller, larger := smallerFirst[K](c, o)
+	smaller.Each(func(v K) {
+		if larger.Includes(v) {
+			count++
+		}
+	})
+	return
+}
+
+// smallerFirst returns the operands ordered by Len, so that the smaller one can be iterated over
+func smallerFirst[K comparable](left, o Immutable[K]) (smaller, larger Immutable[K]) {
+	if o.Len() < left.Len() {
+		return o, left
+	}
+	return left, o
+}
+
+func (c *T[K]) ToSlice() (out []K) {
+	out = make([]K, c.Len())
+	i := 0
+	for s := range c.items {
+		out[i] = s
+		i++
+	}
+	return
+}
+
+func (c *T[K]) Each(item func(v K)) {
+	for value := range c.items {
+		item(value)
+	}
+}
+
+func (c *T[K]) EachCancelable(item func(v K) (next NextAction)) {
+	for value := range c.items {
+		action := item(value)
+		if action == Break {
+			break
+		}
+	}
+}
+
+func (c *T[K]) All() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for value := range c.items {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Sorted returns an iterator over the items of s in ascending order. The items are sorted when iteration begins
+func Sorted[K cmp.Ordered](s Iterator[K]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for _, v := range slices.Sorted(s.All()) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Any returns true if predicate returns true for any item. Short-circuits and stops iteration when didMatch
+// returns true. Returns false if no item caused predicate to return true
+func (c *T[K]) Any(item func(v K) (didMatch bool)) (anyFound bool) {
+	c.EachCancelable(func(v K) (a NextAction) {
+		if item(v) {
+			anyFound = true
+			return Break
+		}
+		return
+	})
+	return
+}
+
+// None return true if predicate returned false for every item in the set. If predicate returns true, short-circuit
+// and return false from this method, indicating that at least 1 item matched
+func (c *T[K]) None(item func(v K) (didMatch bool)) (noneFound bool) {
+	noneFound = true
+	c.EachCancelable(func(v K) (a NextAction) {
+		if item(v) {
+			noneFound = false
+			return Break
+		}
+		return
+	})
+	return
+}
+
+func (c *T[K]) Copy() Interface[K] {
+	outItems := NewWithCapacity[K](c.Len())
+	for s := range c.items {
+		outItems.Add(s)
+	}
+	return outItems
+}
@@ -0,0 +1,283 @@
+package generic_set
+
+import (
+	"github.com/stretchr/testify/assert"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestCollection_Add(t *testing.T) {
+	cases := map[string]struct {
+		input    []int64
+		expected Immutable[int64]
+	}{
+		"empty": {
+			input:    []int64{},
+			expected: New[int64](),
+		},
+		"two with duplicate": {
+			input:    []int64{1, 2, 1},
+			expected: NewOf[int64](1, 2),
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			actual := NewOf(c.input...)
+			assert.True(t, c.expected.IsEqualTo(actual))
+		})
+	}
+}
+
+func TestCollection_Remove(t *testing.T) {
+	set := NewOf[int64](1, 2, 3)
+	set.RemoveMany(1, 4)
+	assert.True(t, NewOf[int64](2, 3).IsEqualTo(set))
+	assert.False(t, set.Includes(1))
+	assert.True(t, set.Includes(2))
+	assert.Equal(t, 2, set.Len())
+	assert.False(t, set.IsEmpty())
+}
+
+func TestCollection_ZeroValue(t *testing.T) {
+	var set T[int64]
+	assert.True(t, set.IsEmpty())
+	assert.False(t, set.Includes(1))
+	set.Remove(1)
+	set.Add(1)
+	assert.True(t, NewOf[int64](1).IsEqualTo(&set))
+
+	var other T[int64]
+	assert.Equal(t, 1, other.SymmetricDifferenceWith(&set))
+	assert.True(t, other.Includes(1))
+}
+
+func TestCollection_ArrayKeys(t *testing.T) {
+	a := [16]byte{1}
+	b := [16]byte{2}
+	set := NewOf(a, b, a)
+	assert.Equal(t, 2, set.Len())
+	assert.True(t, set.Includes([16]byte{1}))
+	assert.False(t, set.Includes([16]byte{3}))
+}
+
+func TestCollection_Setter(t *testing.T) {
+	cases := map[string]struct {
+		a            Immutable[int64]
+		b            Immutable[int64]
+		union        Immutable[int64]
+		subtract     Immutable[int64]
+		intersection Immutable[int64]
+	}{
+		"empty": {
+			a:            New[int64](),
+			b:            New[int64](),
+			union:        New[int64](),
+			subtract:     New[int64](),
+			intersection: New[int64](),
+		},
+		"partial overlap items": {
+			a:            NewOf[int64](1, 2),
+			b:            NewOf[int64](2, 3),
+			union:        NewOf[int64](1, 2, 3),
+			subtract:     NewOf[int64](1),
+			intersection: NewOf[int64](2),
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			assert.True(t, c.union.IsEqualTo(c.a.Union(c.b)))
+			assert.True(t, c.subtract.IsEqualTo(c.a.Subtract(c.b)))
+			assert.True(t, c.intersection.IsEqualTo(c.a.Intersection(c.b)))
+		})
+	}
+}
+
+func TestCollection_Predicates(t *testing.T) {
+	cases := map[string]struct {
+		a                   Immutable[int64]
+		b                   Immutable[int64]
+		symmetricDifference Immutable[int64]
+		subset              bool
+		properSubset        bool
+		superset            bool
+		disjoint            bool
+		intersectionLen     int
+	}{
+		"empty": {
+			a:                   New[int64](),
+			b:                   New[int64](),
+			symmetricDifference: New[int64](),
+			subset:              true,
+			superset:            true,
+			disjoint:            true,
+		},
+		"equal": {
+			a:                   NewOf[int64](1, 2),
+			b:                   NewOf[int64](2, 1),
+			symmetricDifference: New[int64](),
+			subset:              true,
+			superset:            true,
+			intersectionLen:     2,
+		},
+		"proper subset": {
+			a:                   NewOf[int64](1),
+			b:                   NewOf[int64](1, 2, 3),
+			symmetricDifference: NewOf[int64](2, 3),
+			subset:              true,
+			properSubset:        true,
+			intersectionLen:     1,
+		},
+		"superset": {
+			a:                   NewOf[int64](1, 2, 3),
+			b:                   NewOf[int64](3),
+			symmetricDifference: NewOf[int64](1, 2),
+			superset:            true,
+			intersectionLen:     1,
+		},
+		"partial overlap items": {
+			a:                   NewOf[int64](1, 2),
+			b:                   NewOf[int64](2, 3),
+			symmetricDifference: NewOf[int64](1, 3),
+			intersectionLen:     1,
+		},
+		"disjoint": {
+			a:                   NewOf[int64](1, 2),
+			b:                   NewOf[int64](3, 4, 5),
+			symmetricDifference: NewOf[int64](1, 2, 3, 4, 5),
+			disjoint:            true,
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			assert.True(t, c.symmetricDifference.IsEqualTo(c.a.SymmetricDifference(c.b)))
+			assert.True(t, c.symmetricDifference.IsEqualTo(c.b.SymmetricDifference(c.a)))
+			assert.Equal(t, c.subset, c.a.IsSubsetOf(c.b))
+			assert.Equal(t, c.properSubset, c.a.IsProperSubsetOf(c.b))
+			assert.Equal(t, c.superset, c.a.IsSupersetOf(c.b))
+			assert.Equal(t, c.superset, c.b.IsSubsetOf(c.a))
+			assert.Equal(t, c.disjoint, c.a.IsDisjointFrom(c.b))
+			assert.Equal(t, c.disjoint, c.b.IsDisjointFrom(c.a))
+			assert.Equal(t, c.intersectionLen, c.a.IntersectionLen(c.b))
+			assert.Equal(t, c.intersectionLen, c.b.IntersectionLen(c.a))
+		})
+	}
+}
+
+func TestCollection_InPlaceSetter(t *testing.T) {
+	cases := map[string]struct {
+		a                          []int64
+		b                          Immutable[int64]
+		union                      Immutable[int64]
+		unionChanged               int
+		subtract                   Immutable[int64]
+		subtractChanged            int
+		intersection               Immutable[int64]
+		intersectionChanged        int
+		symmetricDifference        Immutable[int64]
+		symmetricDifferenceChanged int
+	}{
+		"empty": {
+			b:                   New[int64](),
+			union:               New[int64](),
+			subtract:            New[int64](),
+			intersection:        New[int64](),
+			symmetricDifference: New[int64](),
+		},
+		"partial overlap items": {
+			a:                          []int64{1, 2},
+			b:                          NewOf[int64](2, 3),
+			union:                      NewOf[int64](1, 2, 3),
+			unionChanged:               1,
+			subtract:                   NewOf[int64](1),
+			subtractChanged:            1,
+			intersection:               NewOf[int64](2),
+			intersectionChanged:        1,
+			symmetricDifference:        NewOf[int64](1, 3),
+			symmetricDifferenceChanged: 2,
+		},
+		"larger operand": {
+			a:                          []int64{1},
+			b:                          NewOf[int64](1, 2, 3),
+			union:                      NewOf[int64](1, 2, 3),
+			unionChanged:               2,
+			subtract:                   New[int64](),
+			subtractChanged:            1,
+			intersection:               NewOf[int64](1),
+			symmetricDifference:        NewOf[int64](2, 3),
+			symmetricDifferenceChanged: 3,
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			union := NewOf[int64](c.a...)
+			assert.Equal(t, c.unionChanged, union.UnionWith(c.b))
+			assert.True(t, c.union.IsEqualTo(union))
+			subtract := NewOf[int64](c.a...)
+			assert.Equal(t, c.subtractChanged, subtract.SubtractWith(c.b))
+			assert.True(t, c.subtract.IsEqualTo(subtract))
+			intersection := NewOf[int64](c.a...)
+			assert.Equal(t, c.intersectionChanged, intersection.IntersectWith(c.b))
+			assert.True(t, c.intersection.IsEqualTo(intersection))
+			symmetricDifference := NewOf[int64](c.a...)
+			assert.Equal(t, c.symmetricDifferenceChanged, symmetricDifference.SymmetricDifferenceWith(c.b))
+			assert.True(t, c.symmetricDifference.IsEqualTo(symmetricDifference))
+		})
+	}
+}
+
+func TestCollection_InPlaceSetterWithItself(t *testing.T) {
+	set := NewOf[int64](1, 2)
+	assert.Equal(t, 0, set.UnionWith(set))
+	assert.Equal(t, 0, set.IntersectWith(set))
+	assert.True(t, NewOf[int64](1, 2).IsEqualTo(set))
+	assert.Equal(t, 2, set.SymmetricDifferenceWith(set))
+	assert.True(t, set.IsEmpty())
+
+	set = NewOf[int64](1, 2)
+	assert.Equal(t, 2, set.SubtractWith(set))
+	assert.True(t, set.IsEmpty())
+}
+
+func TestCollection_EachCancelable(t *testing.T) {
+	input := NewOf[int64](1, 2, 3, 4)
+
+	actual := New[int64]()
+	input.EachCancelable(func(v int64) NextAction {
+		if actual.Len() > 1 {
+			return Break
+		}
+		actual.Add(v)
+		return Continue
+	})
+
+	assert.Equal(t, 2, actual.Len())
+}
+
+func TestCollection_AnyNone(t *testing.T) {
+	set := NewOf[int64](1, 2)
+	assert.True(t, set.Any(func(v int64) bool { return v == 2 }))
+	assert.False(t, set.None(func(v int64) bool { return v == 2 }))
+	assert.True(t, set.None(func(v int64) bool { return v == 3 }))
+}
+
+func TestCollection_CopyToSlice(t *testing.T) {
+	set := NewOf[int64](2, 1)
+	cp := set.Copy()
+	cp.Add(3)
+	actual := set.ToSlice()
+	sort.Slice(actual, func(i, j int) bool { return actual[i] < actual[j] })
+	assert.Equal(t, []int64{1, 2}, actual)
+	assert.Equal(t, 3, cp.Len())
+}
+
+func TestCollection_All(t *testing.T) {
+	set := Collect(slices.Values([]int64{3, 1, 2, 1}))
+	assert.Equal(t, 3, set.Len())
+	assert.ElementsMatch(t, []int64{1, 2, 3}, slices.Collect(set.All()))
+	assert.Equal(t, []int64{1, 2, 3}, slices.Collect(Sorted[int64](set)))
+}
@@ -0,0 +1,156 @@
+package generic_set
+
+import (
+	"iter"
+)
+
+// Adder only allows items to be added. Approximate sets and sketches that cannot remove items, such as Bloom
+// filters, can implement it too
+type Adder[K comparable] interface {
+	// Add an item to the set. If it already exists, this is just skipped and the item remains in the set
+	Add(v K)
+
+	// AddMany items to the set. Ignoring any duplicates
+	AddMany(v ...K)
+}
+
+// Mutable allows you to alter the contents of the set
+type Mutable[K comparable] interface {
+	Adder[K]
+
+	// Remove an item from the set. If it doesn't exist, skip
+	Remove(v K)
+
+	// RemoveMany items from the set. Ignoring any non-existing items
+	RemoveMany(v ...K)
+
+	// UnionWith adds the items of the parameter to the set, without creating a new set. Returns the number of items
+	// added
+	// left = left ∪ o
+	UnionWith(o Immutable[K]) (changed int)
+
+	// SubtractWith removes the items of the parameter from the set, without creating a new set. Returns the number
+	// of items removed
+	// left = left - o
+	SubtractWith(o Immutable[K]) (changed int)
+
+	// IntersectWith removes the items that are not in the parameter from the set, without creating a new set.
+	// Returns the number of items removed
+	// left = left ∩ o
+	IntersectWith(o Immutable[K]) (changed int)
+
+	// SymmetricDifferenceWith removes the items that are in the parameter from the set, and adds the items of the
+	// parameter that were not in the set, without creating a new set. Returns the number of items added or removed
+	// left = (left - o) ∪ (o - left)
+	SymmetricDifferenceWith(o Immutable[K]) (changed int)
+
+	// Apply removes the items d removed and adds the items d added, replaying the changes Diff found on this set.
+	// Items that were already removed or added are skipped. Returns the number of items removed or added
+	Apply(d DiffResult[K]) (changed int)
+}
+
+// Includer is the smallest read-only view of a set: membership tests only. Approximate sets, such as Bloom
+// filters, can implement it too
+type Includer[K comparable] interface {
+	// Includes returns true if the item is in the set, false if not found
+	Includes(v K) bool
+}
+
+// Tester contains read-only methods to query the metadata about the contents of the set
+type Tester[K comparable] interface {
+	Includer[K]
+
+	// IsEmpty returns true if there are no items in the set, false if there is at least 1 item in the set
+	IsEmpty() bool
+
+	// Len returns the number of items in the set
+	Len() int
+
+	// IsEqualTo returns true both sets contain the same items, false if they contain different numbers of items of
+	// values of items differ. Sets don't care about item ordering, so you can add items to the sets in any order and
+	// this will still be true.
+	IsEqualTo(o Immutable[K]) bool
+
+	// IsSubsetOf returns true if every item in the callee is also in the parameter
+	// left ⊆ o
+	IsSubsetOf(o Immutable[K]) bool
+
+	// IsProperSubsetOf returns true if every item in the callee is also in the parameter, and the parameter has
+	// items the callee does not
+	// left ⊂ o
+	IsProperSubsetOf(o Immutable[K]) bool
+
+	// IsSupersetOf returns true if every item in the parameter is also in the callee
+	// left ⊇ o
+	IsSupersetOf(o Immutable[K]) bool
+
+	// IsDisjointFrom returns true if the callee and the parameter have no items in common
+	// left ∩ o = ∅
+	IsDisjointFrom(o Immutable[K]) bool
+
+	// IntersectionLen returns the number of items Intersection would return, without creating the intersection
+	// |left ∩ o|
+	IntersectionLen(o Immutable[K]) int
+}
+
+// Iterator allows callers to loop over the contents of sets
+type Iterator[K comparable] interface {
+	// Each loops over each item in the set. The order is not guaranteed and can change between invocations
+	Each(item func(v K))
+
+	// EachCancelable is just like Each, but you can stop the iteration by returning
+	// Break instead of Continue
+	EachCancelable(item func(v K) (next NextAction))
+
+	// All returns an iterator over each item in the set, for use with range. The order is not guaranteed and can
+	// change between invocations
+	All() iter.Seq[K]
+}
+
+// Copier allows new sets to be created from existing sets
+type Copier[K comparable] interface {
+	// Copy returns a mutable copy of the set. The returned set is shared-nothing, so you can treat this as a safe-copy
+	Copy() Interface[K]
+}
+
+// Slicer converts the set to a slice
+type Slicer[K comparable] interface {
+	// ToSlice returns a slice with the contents of the set. There is no guarantee of the order the items will
+	// be returned in
+	ToSlice() (out []K)
+}
+
+// Setter contains the set-specific methods
+type Setter[K comparable] interface {
+	// Union returns a new set containing all of the items from the callee and the parameter
+	// union = left ∪ o
+	Union(o Immutable[K]) (out Interface[K])
+
+	// Subtract returns a new set containing only items from the callee, but without the items in the parameter
+	// subtracted = left - o
+	Subtract(o Immutable[K]) (out Interface[K])
+
+	// Intersection returns a new set containing only items common to both the callee and parameter
+	// intersection = left ∩ o
+	Intersection(o Immutable[K]) (out Interface[K])
+
+	// SymmetricDifference returns a new set containing the items that are in either the callee or the parameter,
+	// but not in both
+	// symmetricDifference = (left - o) ∪ (o - left)
+	SymmetricDifference(o Immutable[K]) (out Interface[K])
+}
+
+// Immutable contains all of the read-only method calls that do not modify the set
+type Immutable[K comparable] interface {
+	Iterator[K]
+	Slicer[K]
+	Tester[K]
+	Setter[K]
+	Copier[K]
+}
+
+// Interface contains all the methods for a set, Immutable and Mutable
+type Interface[K comparable] interface {
+	Mutable[K]
+	Immutable[K]
+}
@@ -1,10 +1,14 @@
 package string_set
 
-type NextAction uint8
+import (
+	"github.com/wojnosystems/go-string-set/generic_set"
+)
+
+type NextAction = generic_set.NextAction
 
 const (
 	// Continue: keep iterating over the contents of the set
-	Continue NextAction = iota
+	Continue = generic_set.Continue
 	// Break: do not iterate over the remaining contents of the set
-	Break
+	Break = generic_set.Break
 )
@@ -0,0 +1,230 @@
+package string_set
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"slices"
+)
+
+const (
+	// binaryFormatVersion is written in the header of EncodeBinary's output
+	binaryFormatVersion = 1
+	binaryHeaderLen     = 4 + 1 + 1
+	binaryChecksumLen   = 4
+
+	// flagFrontCoded is set when the items are sorted and each one only stores the part that differs from the
+	// item before it
+	flagFrontCoded = 1 << 0
+)
+
+var binaryMagic = [4]byte{'S', 'S', 'E', 'T'}
+
+// DefaultMaxBinaryDecodedLen is the most bytes the strings decoded by DecodeBinary may add up to when
+// BinaryOptions.MaxDecodedLen is not set
+const DefaultMaxBinaryDecodedLen = 256 << 20
+
+var (
+	// ErrInvalidFormat is returned when decoding data that is not a set written by EncodeBinary
+	ErrInvalidFormat = errors.New("string_set: invalid binary format")
+	// ErrChecksumMismatch is returned when decoding a set whose data was changed after it was written, such as a
+	// corrupted or truncated cache file
+	ErrChecksumMismatch = errors.New("string_set: binary checksum mismatch")
+	// ErrDecodedTooLarge is returned when the strings in the data would add up to more than
+	// BinaryOptions.MaxDecodedLen bytes
+	ErrDecodedTooLarge = errors.New("string_set: decoded binary set is too large")
+)
+
+// UnsupportedVersionError is returned when decoding a set written by a newer version of EncodeBinary
+type UnsupportedVersionError struct {
+	Version byte
+}
+
+func (e *UnsupportedVersionError) Error() string {
+	return fmt.Sprintf("string_set: unsupported binary format version %d", e.Version)
+}
+
+// BinaryOptions controls how a set is encoded by EncodeBinary and decoded by DecodeBinary, and by Binary
+type BinaryOptions struct {
+	// Unsorted writes the items in iteration order, each with its full length. It's faster to encode, but the
+	// output is larger and differs between runs. When false, the items are sorted and front-coded: each item
+	// only stores the length of the prefix it shares with the item before it, and the rest of its bytes
+	Unsorted bool
+	// MaxDecodedLen is the most bytes the decoded strings may add up to. As front-coded items reuse the item before
+	// them, a few bytes of data can decode to a long string each, so this bounds the memory decoding uses. Defaults
+	// to DefaultMaxBinaryDecodedLen
+	MaxDecodedLen int
+}
+
+func (o BinaryOptions) maxDecodedLen() uint64 {
+	if o.MaxDecodedLen <= 0 {
+		return DefaultMaxBinaryDecodedLen
+	}
+	return uint64(o.MaxDecodedLen)
+}
+
+// EncodeBinary returns the contents of s in a compact binary format: a header with a format version, the number of
+// items, the length-prefixed items, and a CRC-32 checksum of everything before it
+func EncodeBinary(s Immutable, opts BinaryOptions) ([]byte, error) {
+	items := s.ToSlice()
+	var flags byte
+	if !opts.Unsorted {
+		slices.Sort(items)
+		flags |= flagFrontCoded
+	}
+	out := make([]byte, 0, binaryHeaderLen+binary.MaxVarintLen64+binaryChecksumLen)
+	out = append(out, binaryMagic[:]...)
+	out = append(out, binaryFormatVersion, flags)
+	out = binary.AppendUvarint(out, uint64(len(items)))
+	previous := ""
+	for _, item := range items {
+		suffix := item
+		if flags&flagFrontCoded != 0 {
+			shared := commonPrefixLen(previous, item)
+			out = binary.AppendUvarint(out, uint64(shared))
+			suffix = item[shared:]
+			previous = item
+		}
+		out = binary.AppendUvarint(out, uint64(len(suffix)))
+		out = append(out, suffix...)
+	}
+	return binary.BigEndian.AppendUint32(out, crc32.ChecksumIEEE(out)), nil
+}
+
+// DecodeBinary adds each string encoded in data by EncodeBinary to into. Returns ErrChecksumMismatch if data was
+// corrupted, or ErrDecodedTooLarge if the strings add up to more than opts.MaxDecodedLen bytes, in which case
+// nothing is added
+func DecodeBinary(data []byte, into Interface, opts BinaryOptions) error {
+	if len(data) < binaryHeaderLen+binaryChecksumLen || [4]byte(data[:4]) != binaryMagic {
+		return ErrInvalidFormat
+	}
+	if data[4] != binaryFormatVersion {
+		return &UnsupportedVersionError{Version: data[4]}
+	}
+	body, checksum := data[:len(data)-binaryChecksumLen], data[len(data)-binaryChecksumLen:]
+	if crc32.ChecksumIEEE(body) != binary.BigEndian.Uint32(checksum) {
+		return ErrChecksumMismatch
+	}
+	flags := body[5]
+	if flags&^flagFrontCoded != 0 {
+		return ErrInvalidFormat
+	}
+	r := binaryReader{data: body[binaryHeaderLen:]}
+	count := r.uvarint()
+	// every item takes at least a byte, which bounds how many items the data can hold
+	if r.failed || count > uint64(len(r.data)) {
+		return ErrInvalidFormat
+	}
+	items := make([]string, 0, count)
+	previous := ""
+	remaining := opts.maxDecodedLen()
+	for i := uint64(0); i < count; i++ {
+		prefix := ""
+		if flags&flagFrontCoded != 0 {
+			shared := r.uvarint()
+			if shared > uint64(len(previous)) {
+				return ErrInvalidFormat
+			}
+			prefix = previous[:shared]
+		}
+		suffix := r.bytes(r.uvarint())
+		if r.failed {
+			return ErrInvalidFormat
+		}
+		// checked before the item is built, so that the limit also bounds what is allocated
+		length := uint64(len(prefix) + len(suffix))
+		if length > remaining {
+			return ErrDecodedTooLarge
+		}
+		remaining -= length
+		item := prefix + string(suffix)
+		items = append(items, item)
+		previous = item
+	}
+	if len(r.data) != 0 {
+		return ErrInvalidFormat
+	}
+	into.AddMany(items...)
+	return nil
+}
+
+// binaryReader reads the parts of EncodeBinary's output. Once a read runs past the end of data, failed is set and
+// every later read returns nothing
+type binaryReader struct {
+	data   []byte
+	failed bool
+}
+
+func (r *binaryReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.failed = true
+		r.data = nil
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+func (r *binaryReader) bytes(n uint64) []byte {
+	if n > uint64(len(r.data)) {
+		r.failed = true
+		r.data = nil
+		return nil
+	}
+	out := r.data[:n]
+	r.data = r.data[n:]
+	return out
+}
+
+func commonPrefixLen(a, b string) int {
+	n := min(len(a), len(b))
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
+
+// Binary encodes Set with EncodeBinary and Options, such as leaving the items unsorted. Use it in place of the set
+// when the defaults of T.MarshalBinary do not suit. A nil Set is encoded as an empty set, and decoded into a new *T
+type Binary struct {
+	Set     *T
+	Options BinaryOptions
+}
+
+// MarshalBinary encodes the set with EncodeBinary and Options
+func (b Binary) MarshalBinary() ([]byte, error) {
+	return EncodeBinary(orEmpty(b.Set), b.Options)
+}
+
+// UnmarshalBinary replaces the contents of the set with the strings encoded by MarshalBinary, whatever the options
+// they were encoded with. Options.MaxDecodedLen limits how large the set may be. The set is left unchanged if data
+// is invalid, corrupted or too large
+func (b *Binary) UnmarshalBinary(data []byte) error {
+	decoded := NewWithCapacity(0)
+	if err := DecodeBinary(data, decoded, b.Options); err != nil {
+		return err
+	}
+	b.Set = replaced(b.Set, decoded)
+	return nil
+}
+
+// MarshalBinary encodes the set with EncodeBinary, sorted and front-coded. Use Binary to choose other options
+func (c *T) MarshalBinary() ([]byte, error) {
+	return EncodeBinary(c, BinaryOptions{})
+}
+
+// UnmarshalBinary replaces the contents of the set with the strings encoded by MarshalBinary. A zero-value T may be
+// used. The set is left unchanged if data is invalid, corrupted or decodes to more than DefaultMaxBinaryDecodedLen
+// bytes of strings. Use Binary to choose another limit
+func (c *T) UnmarshalBinary(data []byte) error {
+	return (&Binary{Set: c}).UnmarshalBinary(data)
+}
+
+// MarshalBinary encodes the snapshot with EncodeBinary, sorted and front-coded
+func (f *Frozen) MarshalBinary() ([]byte, error) {
+	return EncodeBinary(f, BinaryOptions{})
+}
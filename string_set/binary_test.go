@@ -0,0 +1,167 @@
+package string_set
+
+import (
+	"encoding"
+	"encoding/binary"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"hash/crc32"
+	"strconv"
+	"testing"
+)
+
+var _ encoding.BinaryMarshaler = &T{}
+var _ encoding.BinaryUnmarshaler = &T{}
+
+func TestCollection_BinaryRoundTrip(t *testing.T) {
+	cases := map[string]struct {
+		input    *T
+		opts     BinaryOptions
+		expected []string
+	}{
+		"empty": {
+			input: New(),
+		},
+		"zero value": {
+			input: &T{},
+		},
+		"items": {
+			input:    NewOf("b", "a", "c"),
+			expected: []string{"a", "b", "c"},
+		},
+		"empty string": {
+			input:    NewOf("", "a"),
+			expected: []string{"", "a"},
+		},
+		"shared prefixes": {
+			input:    NewOf("apple", "app", "application", "apply", "banana"),
+			expected: []string{"app", "apple", "application", "apply", "banana"},
+		},
+		"unicode": {
+			input:    NewOf("naïve", "naïvety", "日本", "日本語"),
+			expected: []string{"naïve", "naïvety", "日本", "日本語"},
+		},
+		"unsorted": {
+			input:    NewOf("apple", "app", "banana"),
+			opts:     BinaryOptions{Unsorted: true},
+			expected: []string{"app", "apple", "banana"},
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			data, err := Binary{Set: c.input, Options: c.opts}.MarshalBinary()
+			assert.NoError(t, err)
+
+			actual := &T{}
+			assert.NoError(t, actual.UnmarshalBinary(data))
+			assert.True(t, NewOf(c.expected...).IsEqualTo(actual))
+		})
+	}
+}
+
+func TestCollection_MarshalBinaryIsDeterministic(t *testing.T) {
+	var items []string
+	for i := 0; i < 100; i++ {
+		items = append(items, strconv.Itoa(i))
+	}
+	first, _ := NewOf(items...).MarshalBinary()
+	for i := 0; i < 10; i++ {
+		again, _ := NewOf(items...).MarshalBinary()
+		assert.Equal(t, first, again)
+	}
+	frozen, _ := NewOf(items...).Freeze().MarshalBinary()
+	assert.Equal(t, first, frozen)
+}
+
+func TestCollection_MarshalBinaryIsSmallerThanJSON(t *testing.T) {
+	set := New()
+	for i := 0; i < 1000; i++ {
+		set.Add("https://example.com/items/" + strconv.Itoa(i))
+	}
+	frontCoded, _ := set.MarshalBinary()
+	unsorted, _ := Binary{Set: set, Options: BinaryOptions{Unsorted: true}}.MarshalBinary()
+	asJSON, _ := json.Marshal(set)
+
+	assert.Less(t, len(unsorted), len(asJSON))
+	assert.Less(t, len(frontCoded)*3, len(unsorted))
+}
+
+func TestCollection_UnmarshalBinaryErrors(t *testing.T) {
+	valid, _ := NewOf("apple", "apply", "banana").MarshalBinary()
+	withVersion := func(version byte) []byte {
+		out := append([]byte{}, valid...)
+		out[4] = version
+		return out
+	}
+
+	cases := map[string]struct {
+		input       []byte
+		expectedErr error
+	}{
+		"nil": {
+			expectedErr: ErrInvalidFormat,
+		},
+		"not a set": {
+			input:       []byte(`["apple","banana"]`),
+			expectedErr: ErrInvalidFormat,
+		},
+		"truncated": {
+			input:       valid[:len(valid)-1],
+			expectedErr: ErrChecksumMismatch,
+		},
+		"extra data": {
+			input:       append(append([]byte{}, valid...), 0),
+			expectedErr: ErrChecksumMismatch,
+		},
+		"newer version": {
+			input:       withVersion(2),
+			expectedErr: &UnsupportedVersionError{Version: 2},
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			actual := NewOf("unchanged")
+			assert.Equal(t, c.expectedErr, actual.UnmarshalBinary(c.input))
+			assert.True(t, actual.IsEqualTo(NewOf("unchanged")))
+		})
+	}
+}
+
+func TestCollection_UnmarshalBinaryLimitsDecodedLen(t *testing.T) {
+	// each item is front-coded as the whole item before it plus one byte, so about 4 bytes of data per item add up
+	// to 1+2+...+count bytes of strings
+	growingPrefix := func(count int) []byte {
+		out := append(binaryMagic[:], binaryFormatVersion, flagFrontCoded)
+		out = binary.AppendUvarint(out, uint64(count))
+		for i := 0; i < count; i++ {
+			out = binary.AppendUvarint(out, uint64(i))
+			out = append(binary.AppendUvarint(out, 1), 'a')
+		}
+		return binary.BigEndian.AppendUint32(out, crc32.ChecksumIEEE(out))
+	}
+
+	data := growingPrefix(1000)
+	assert.Less(t, len(data), 5000)
+	actual := &Binary{Set: NewOf("unchanged"), Options: BinaryOptions{MaxDecodedLen: 1000 * 1001 / 2}}
+	assert.NoError(t, actual.UnmarshalBinary(data))
+	assert.Equal(t, 1000, actual.Set.Len())
+
+	actual = &Binary{Set: NewOf("unchanged"), Options: BinaryOptions{MaxDecodedLen: 1000*1001/2 - 1}}
+	assert.Equal(t, ErrDecodedTooLarge, actual.UnmarshalBinary(data))
+	assert.True(t, actual.Set.IsEqualTo(NewOf("unchanged")))
+
+	assert.Equal(t, uint64(DefaultMaxBinaryDecodedLen), BinaryOptions{}.maxDecodedLen())
+}
+
+func TestCollection_UnmarshalBinaryDetectsCorruption(t *testing.T) {
+	valid, _ := NewOf("apple", "apply", "banana").MarshalBinary()
+	for i := range valid {
+		for _, bit := range []byte{0x01, 0x80} {
+			corrupted := append([]byte{}, valid...)
+			corrupted[i] ^= bit
+			assert.Error(t, New().UnmarshalBinary(corrupted), "byte %d, bit %#x", i, bit)
+		}
+	}
+}
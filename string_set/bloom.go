@@ -0,0 +1,19 @@
+package string_set
+
+import (
+	"github.com/wojnosystems/go-string-set/string_set_bloom"
+)
+
+// ToBloom returns a Bloom filter containing the items of the set, sized for the set's Len and falsePositiveRate,
+// which must be between 0 and 1, exclusive. The filter is much smaller than the set, but may claim to include
+// strings that the set does not
+func (c *T) ToBloom(falsePositiveRate float64) *string_set_bloom.Filter {
+	out := string_set_bloom.New(c.Len(), falsePositiveRate)
+	c.Each(out.Add)
+	return out
+}
+
+// ToBloom returns a Bloom filter containing the items of the snapshot. See T.ToBloom
+func (f *Frozen) ToBloom(falsePositiveRate float64) *string_set_bloom.Filter {
+	return f.t.ToBloom(falsePositiveRate)
+}
@@ -0,0 +1,224 @@
+package string_set
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/wojnosystems/go-string-set/generic_set"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultDiffContext is the number of unchanged items shown around each change when UnifiedDiffOptions.Context
+	// is not set, the same as diff -u
+	DefaultDiffContext = 3
+)
+
+// DiffResult describes how one set changed into another. Please use Diff to create one
+type DiffResult = generic_set.DiffResult[string]
+
+// Diff returns the changes that turn old into new. Items are compared the way CompareKey describes; Unchanged keeps
+// the spelling of old
+func Diff(old, new Immutable) DiffResult {
+	return generic_set.Diff[string](old, new)
+}
+
+// UnifiedDiffOptions controls how WriteUnifiedDiff renders a DiffResult
+type UnifiedDiffOptions struct {
+	// OldName and NewName label the old and new sets in the header. Default to "old" and "new"
+	OldName string
+	NewName string
+	// Context is the number of unchanged items shown before and after each change. Defaults to DefaultDiffContext
+	Context int
+	// NoContext shows only the items that changed, ignoring Context
+	NoContext bool
+}
+
+// FormatDiff renders d for people to read: the summary of DiffResult.String, then a line for each added item
+// starting with "+ ", then a line for each removed item starting with "- ", in lexical order. Items containing line
+// breaks are quoted
+func FormatDiff(d DiffResult) string {
+	var b strings.Builder
+	b.WriteString(d.String())
+	b.WriteByte('\n')
+	for _, v := range SortedSlice(d.Added) {
+		b.WriteString("+ " + diffText(v) + "\n")
+	}
+	for _, v := range SortedSlice(d.Removed) {
+		b.WriteString("- " + diffText(v) + "\n")
+	}
+	return b.String()
+}
+
+// UnifiedDiff returns what WriteUnifiedDiff writes, as a string
+func UnifiedDiff(d DiffResult, opts UnifiedDiffOptions) string {
+	var b strings.Builder
+	_ = WriteUnifiedDiff(&b, d, opts)
+	return b.String()
+}
+
+// WriteUnifiedDiff writes d to w in the unified format of diff -u, as if comparing files holding the old and new
+// sets as WriteTo writes them: one item per line, in lexical order. This makes the output familiar to reviewers,
+// and lets patch tools apply it to those files. Writes nothing when d has no changes. Items containing line breaks
+// are quoted
+func WriteUnifiedDiff(w io.Writer, d DiffResult, opts UnifiedDiffOptions) error {
+	if !d.HasChanges() {
+		return nil
+	}
+	lines := diffEntriesOf(d)
+	buffered := bufio.NewWriter(w)
+	_, _ = fmt.Fprintf(buffered, "--- %s\n+++ %s\n", opts.oldName(), opts.newName())
+	context := opts.context()
+	for start := 0; start < len(lines); {
+		first := nextChange(lines, start)
+		if first == len(lines) {
+			break
+		}
+		// a hunk continues while the next change is close enough for the context around both to overlap
+		last := first
+		for {
+			next := nextChange(lines, last+1)
+			if next == len(lines) || next-last-1 > 2*context {
+				break
+			}
+			last = next
+		}
+		writeHunk(buffered, lines[max(first-context, 0):min(last+context+1, len(lines))])
+		start = last + 1
+	}
+	return buffered.Flush()
+}
+
+func (o UnifiedDiffOptions) oldName() string {
+	if o.OldName == "" {
+		return "old"
+	}
+	return o.OldName
+}
+
+func (o UnifiedDiffOptions) newName() string {
+	if o.NewName == "" {
+		return "new"
+	}
+	return o.NewName
+}
+
+func (o UnifiedDiffOptions) context() int {
+	switch {
+	case o.NoContext:
+		return 0
+	case o.Context <= 0:
+		return DefaultDiffContext
+	}
+	return o.Context
+}
+
+// diffEntry is a line of a unified diff. oldLine and newLine are the 1-based line numbers the item has, or would
+// have been written after, in the old and new files
+type diffEntry struct {
+	op      byte
+	value   string
+	oldLine int
+	newLine int
+}
+
+// diffEntriesOf returns the lines of both files in lexical order, each marked as removed, added or unchanged
+func diffEntriesOf(d DiffResult) (lines []diffEntry) {
+	add := func(s Immutable, op byte) {
+		s.Each(func(v string) {
+			lines = append(lines, diffEntry{op: op, value: v})
+		})
+	}
+	add(d.Removed, '-')
+	add(d.Added, '+')
+	add(d.Unchanged, ' ')
+	sort.Slice(lines, func(i, j int) bool {
+		if lines[i].value != lines[j].value {
+			return lines[i].value < lines[j].value
+		}
+		return lines[i].op == '-' && lines[j].op != '-'
+	})
+	// like diff, each run of changes between unchanged lines lists its removed lines before its added lines
+	for start := 0; start < len(lines); {
+		end := start
+		for end < len(lines) && lines[end].op != ' ' {
+			end++
+		}
+		sort.SliceStable(lines[start:end], func(i, j int) bool {
+			return lines[start+i].op == '-' && lines[start+j].op == '+'
+		})
+		start = end + 1
+	}
+	oldLine, newLine := 0, 0
+	for i := range lines {
+		if lines[i].op != '+' {
+			oldLine++
+		}
+		if lines[i].op != '-' {
+			newLine++
+		}
+		lines[i].oldLine, lines[i].newLine = oldLine, newLine
+	}
+	return
+}
+
+// nextChange returns the index of the first added or removed line at or after start, or len(lines) if there is none
+func nextChange(lines []diffEntry, start int) int {
+	for i := start; i < len(lines); i++ {
+		if lines[i].op != ' ' {
+			return i
+		}
+	}
+	return len(lines)
+}
+
+// writeHunk writes the header and lines of a hunk
+func writeHunk(w *bufio.Writer, hunk []diffEntry) {
+	oldCount, newCount := 0, 0
+	for _, line := range hunk {
+		if line.op != '+' {
+			oldCount++
+		}
+		if line.op != '-' {
+			newCount++
+		}
+	}
+	// the line numbers of the first line in the hunk, or of the line before it when the hunk has none in that file
+	oldStart, newStart := hunk[0].oldLine, hunk[0].newLine
+	if hunk[0].op == '+' {
+		oldStart++
+	}
+	if hunk[0].op == '-' {
+		newStart++
+	}
+	if oldCount == 0 {
+		oldStart--
+	}
+	if newCount == 0 {
+		newStart--
+	}
+	_, _ = fmt.Fprintf(w, "@@ -%s +%s @@\n", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount))
+	for _, line := range hunk {
+		_ = w.WriteByte(line.op)
+		_, _ = w.WriteString(diffText(line.value))
+		_ = w.WriteByte('\n')
+	}
+}
+
+// hunkRange formats the start and length of a hunk the way diff -u does, leaving out a length of 1
+func hunkRange(start, count int) string {
+	if count == 1 {
+		return strconv.Itoa(start)
+	}
+	return strconv.Itoa(start) + "," + strconv.Itoa(count)
+}
+
+// diffText returns v as it is shown in a diff, quoted if it contains a line break
+func diffText(v string) string {
+	if strings.ContainsAny(v, "\r\n") {
+		return strconv.Quote(v)
+	}
+	return v
+}
@@ -0,0 +1,147 @@
+package string_set
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	cases := map[string]struct {
+		old       Immutable
+		new       Immutable
+		added     []string
+		removed   []string
+		unchanged []string
+	}{
+		"empty": {
+			old: Empty,
+			new: Empty,
+		},
+		"added": {
+			old:       NewOf("a"),
+			new:       NewOf("a", "b"),
+			added:     []string{"b"},
+			unchanged: []string{"a"},
+		},
+		"removed": {
+			old:       NewOf("a", "b").Freeze(),
+			new:       NewOf("b"),
+			removed:   []string{"a"},
+			unchanged: []string{"b"},
+		},
+		"added and removed": {
+			old:       NewOf("a", "b", "c"),
+			new:       NewOf("b", "c", "d", "e"),
+			added:     []string{"d", "e"},
+			removed:   []string{"a"},
+			unchanged: []string{"b", "c"},
+		},
+		"normalized keeps the spelling of old": {
+			old:       NewOf("A", "b"),
+			new:       NewNormalizedOf(strings.ToLower, "a"),
+			removed:   []string{"b"},
+			unchanged: []string{"A"},
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			actual := Diff(c.old, c.new)
+			assert.ElementsMatch(t, c.added, actual.Added.ToSlice())
+			assert.ElementsMatch(t, c.removed, actual.Removed.ToSlice())
+			assert.ElementsMatch(t, c.unchanged, actual.Unchanged.ToSlice())
+			assert.Equal(t, len(c.added)+len(c.removed) > 0, actual.HasChanges())
+
+			patched := c.old.Copy()
+			assert.Equal(t, len(c.added)+len(c.removed), patched.Apply(actual))
+			assert.True(t, c.new.IsEqualTo(patched))
+			assert.Equal(t, 0, patched.Apply(actual), "replaying a diff twice changes nothing")
+		})
+	}
+}
+
+func TestFormatDiff(t *testing.T) {
+	actual := FormatDiff(Diff(NewOf("a", "b", "c"), NewOf("c", "e", "d", "multi\nline")))
+	assert.Equal(t, `3 added, 2 removed, 1 unchanged
++ d
++ e
++ "multi\nline"
+- a
+- b
+`, actual)
+	assert.Equal(t, "0 added, 0 removed, 1 unchanged\n", FormatDiff(Diff(NewOf("a"), NewOf("a"))))
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	old := NewOf("alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel", "india", "juliett", "kilo")
+	updated := old.Copy()
+	updated.Remove("bravo")
+	updated.Add("bravo2")
+	updated.Add("lima")
+
+	cases := map[string]struct {
+		opts     UnifiedDiffOptions
+		expected string
+	}{
+		"default context": {
+			opts: UnifiedDiffOptions{OldName: "allow.txt", NewName: "allow.txt"},
+			expected: `--- allow.txt
++++ allow.txt
+@@ -1,5 +1,5 @@
+ alpha
+-bravo
++bravo2
+ charlie
+ delta
+ echo
+@@ -9,3 +9,4 @@
+ india
+ juliett
+ kilo
++lima
+`,
+		},
+		"hunks merge when their context overlaps": {
+			opts: UnifiedDiffOptions{Context: 5},
+			expected: `--- old
++++ new
+@@ -1,11 +1,12 @@
+ alpha
+-bravo
++bravo2
+ charlie
+ delta
+ echo
+ foxtrot
+ golf
+ hotel
+ india
+ juliett
+ kilo
++lima
+`,
+		},
+		"no context": {
+			opts: UnifiedDiffOptions{NoContext: true},
+			expected: `--- old
++++ new
+@@ -2 +2 @@
+-bravo
++bravo2
+@@ -11,0 +12 @@
++lima
+`,
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			assert.Equal(t, c.expected, UnifiedDiff(Diff(old, updated), c.opts))
+		})
+	}
+}
+
+func TestUnifiedDiff_NoChanges(t *testing.T) {
+	assert.Equal(t, "", UnifiedDiff(Diff(NewOf("a"), NewOf("a")), UnifiedDiffOptions{}))
+}
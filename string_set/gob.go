@@ -0,0 +1,17 @@
+package string_set
+
+// GobEncode encodes the set for encoding/gob in the format of EncodeBinary. The items are always sorted, so that
+// equal sets encode to the same bytes
+func (c *T) GobEncode() ([]byte, error) {
+	return EncodeBinary(c, BinaryOptions{})
+}
+
+// GobDecode replaces the contents of the set with the strings encoded by GobEncode. A zero-value T may be used
+func (c *T) GobDecode(data []byte) error {
+	return c.UnmarshalBinary(data)
+}
+
+// GobEncode encodes the snapshot for encoding/gob in the format of EncodeBinary, with the items sorted
+func (f *Frozen) GobEncode() ([]byte, error) {
+	return EncodeBinary(f, BinaryOptions{})
+}
@@ -0,0 +1,51 @@
+package string_set
+
+import (
+	"bytes"
+	"encoding/gob"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type gobDocument struct {
+	Tags *T
+}
+
+func TestCollection_Gob(t *testing.T) {
+	cases := map[string]struct {
+		input *T
+	}{
+		"empty": {
+			input: New(),
+		},
+		"zero value": {
+			input: &T{},
+		},
+		"items": {
+			input: NewOf("c", "a", "b"),
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			buffer := bytes.Buffer{}
+			assert.NoError(t, gob.NewEncoder(&buffer).Encode(gobDocument{Tags: c.input}))
+
+			var actual gobDocument
+			assert.NoError(t, gob.NewDecoder(&buffer).Decode(&actual))
+			assert.True(t, c.input.IsEqualTo(actual.Tags))
+		})
+	}
+}
+
+func TestCollection_GobEncodeIsSorted(t *testing.T) {
+	set := NewOf("c", "a", "b")
+	actual, err := set.GobEncode()
+	assert.NoError(t, err)
+	expected, _ := NewOf("a", "b", "c").MarshalBinary()
+	assert.Equal(t, expected, actual)
+
+	frozen, err := set.Freeze().GobEncode()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, frozen)
+}
@@ -0,0 +1,116 @@
+package string_set
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// JSONOptions controls how a set is decoded from JSON
+type JSONOptions struct {
+	// AllowDuplicates tolerates a value appearing more than once in the JSON array. When false, decoding a
+	// repeated value fails with a *DuplicateValueError
+	AllowDuplicates bool
+}
+
+// DuplicateValueError is returned when decoding a set that lists the same value more than once
+type DuplicateValueError struct {
+	Value string
+}
+
+func (e *DuplicateValueError) Error() string {
+	return fmt.Sprintf("string_set: duplicate value %q", e.Value)
+}
+
+// SortedSlice returns the items of s in lexical order
+func SortedSlice(s Immutable) []string {
+	items := s.ToSlice()
+	sort.Strings(items)
+	return items
+}
+
+// EncodeJSON returns the contents of s as a JSON array, sorted lexically so that the output is deterministic
+func EncodeJSON(s Immutable) ([]byte, error) {
+	return json.Marshal(SortedSlice(s))
+}
+
+// DecodeJSON adds each string of the JSON array in data to into. A value is a duplicate if into already includes
+// it, so duplicates are detected using into's own comparison rules. JSON null adds nothing
+func DecodeJSON(data []byte, into Interface, opts JSONOptions) error {
+	var items []string
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	return addDecoded(into, items, opts.AllowDuplicates)
+}
+
+// addDecoded adds items to into. Unless allowDuplicates is set, an item that into already includes is an error
+func addDecoded(into Interface, items []string, allowDuplicates bool) error {
+	for _, item := range items {
+		if !allowDuplicates && into.Includes(item) {
+			return &DuplicateValueError{Value: item}
+		}
+		into.Add(item)
+	}
+	return nil
+}
+
+// JSON encodes and decodes Set as a JSON array, decoding with Options. Use it in place of the set, as a struct field
+// or as the argument to json.Unmarshal, when the defaults of T.UnmarshalJSON do not suit. A nil Set is encoded as
+// an empty array, and decoded into a new *T
+type JSON struct {
+	Set     *T
+	Options JSONOptions
+}
+
+// MarshalJSON encodes the set as a JSON array of strings in lexical order
+func (j JSON) MarshalJSON() ([]byte, error) {
+	return EncodeJSON(orEmpty(j.Set))
+}
+
+// UnmarshalJSON replaces the contents of the set with the strings in a JSON array. JSON null leaves the set
+// unchanged. Repeated values are rejected unless Options allows them
+func (j *JSON) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	decoded := NewWithCapacity(0)
+	if err := DecodeJSON(data, decoded, j.Options); err != nil {
+		return err
+	}
+	j.Set = replaced(j.Set, decoded)
+	return nil
+}
+
+// MarshalJSON encodes the set as a JSON array of strings in lexical order
+func (c *T) MarshalJSON() ([]byte, error) {
+	return EncodeJSON(c)
+}
+
+// UnmarshalJSON replaces the contents of the set with the strings in a JSON array. A zero-value T may be used, and
+// JSON null leaves the set unchanged. Repeated values are rejected; use JSON to allow them
+func (c *T) UnmarshalJSON(data []byte) error {
+	return (&JSON{Set: c}).UnmarshalJSON(data)
+}
+
+// orEmpty returns s, or Empty if s is nil, so that a nil set can be encoded
+func orEmpty(s *T) Immutable {
+	if s == nil {
+		return Empty
+	}
+	return s
+}
+
+// replaced returns c with its contents replaced by those of decoded, or decoded itself if c is nil
+func replaced(c, decoded *T) *T {
+	if c == nil {
+		return decoded
+	}
+	c.T = decoded.T
+	return c
+}
+
+// MarshalJSON encodes the snapshot as a JSON array of strings in lexical order
+func (f *Frozen) MarshalJSON() ([]byte, error) {
+	return EncodeJSON(f)
+}
@@ -0,0 +1,130 @@
+package string_set
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCollection_MarshalJSON(t *testing.T) {
+	cases := map[string]struct {
+		input    json.Marshaler
+		expected string
+	}{
+		"empty": {
+			input:    New(),
+			expected: `[]`,
+		},
+		"zero value": {
+			input:    &T{},
+			expected: `[]`,
+		},
+		"sorted": {
+			input:    NewOf("c", "a", "b"),
+			expected: `["a","b","c"]`,
+		},
+		"frozen": {
+			input:    NewOf("b", "a").Freeze(),
+			expected: `["a","b"]`,
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			actual, err := json.Marshal(c.input)
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, string(actual))
+		})
+	}
+}
+
+func TestCollection_UnmarshalJSON(t *testing.T) {
+	cases := map[string]struct {
+		input       string
+		opts        JSONOptions
+		expected    Immutable
+		expectedErr error
+	}{
+		"empty": {
+			input:    `[]`,
+			expected: Empty,
+		},
+		"null": {
+			input:    `null`,
+			expected: Empty,
+		},
+		"items": {
+			input:    `["b","a"]`,
+			expected: NewOf("a", "b"),
+		},
+		"duplicates rejected": {
+			input:       `["a","b","a"]`,
+			expectedErr: &DuplicateValueError{Value: "a"},
+		},
+		"duplicates allowed": {
+			input:    `["a","b","a"]`,
+			opts:     JSONOptions{AllowDuplicates: true},
+			expected: NewOf("a", "b"),
+		},
+		"case differs is not a duplicate": {
+			input:    `["a","A"]`,
+			expected: NewOf("a", "A"),
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			actual := &JSON{Set: &T{}, Options: c.opts}
+			err := json.Unmarshal([]byte(c.input), actual)
+			if c.expectedErr != nil {
+				assert.Equal(t, c.expectedErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, c.expected.IsEqualTo(actual.Set))
+		})
+	}
+}
+
+func TestCollection_UnmarshalJSONNullIsNoOp(t *testing.T) {
+	set := NewOf("a")
+	assert.NoError(t, json.Unmarshal([]byte(`null`), set))
+	assert.True(t, NewOf("a").IsEqualTo(set))
+
+	var wrapped JSON
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &wrapped))
+	assert.Nil(t, wrapped.Set)
+}
+
+func TestJSON_StructField(t *testing.T) {
+	type config struct {
+		Tags JSON `json:"tags"`
+	}
+	actual := config{Tags: JSON{Options: JSONOptions{AllowDuplicates: true}}}
+	assert.NoError(t, json.Unmarshal([]byte(`{"tags":["x","y","x"]}`), &actual))
+	assert.True(t, NewOf("x", "y").IsEqualTo(actual.Tags.Set))
+
+	data, err := json.Marshal(actual)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"tags":["x","y"]}`, string(data))
+
+	data, err = json.Marshal(config{})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"tags":[]}`, string(data))
+}
+
+func TestCollection_JSONStructField(t *testing.T) {
+	type config struct {
+		Tags  *T `json:"tags"`
+		Other T  `json:"other"`
+	}
+	var actual config
+	err := json.Unmarshal([]byte(`{"tags":["x","y"],"other":["z"]}`), &actual)
+	assert.NoError(t, err)
+	assert.True(t, NewOf("x", "y").IsEqualTo(actual.Tags))
+	assert.True(t, NewOf("z").IsEqualTo(&actual.Other))
+
+	out, err := json.Marshal(&actual)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"tags":["x","y"],"other":["z"]}`, string(out))
+}
@@ -0,0 +1,239 @@
+package string_set
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+const (
+	// DefaultLineCommentPrefix starts a comment line when LineOptions.CommentPrefix is empty
+	DefaultLineCommentPrefix = "#"
+	// DefaultMaxLineLength is the longest line, in bytes, accepted when LineOptions.MaxLineLength is not set
+	DefaultMaxLineLength = 64 * 1024
+
+	byteOrderMark = "\uFEFF"
+)
+
+var (
+	// ErrLineTooLong is returned, in a *LineError, when reading a line longer than LineOptions.MaxLineLength
+	ErrLineTooLong = errors.New("string_set: line too long")
+	// ErrUnwritableItem is returned, wrapped with the item, when writing an item that would not be read back as
+	// itself, such as one containing a line break
+	ErrUnwritableItem = errors.New("string_set: item cannot be written as a line")
+)
+
+// LineError is returned when a line cannot be read. Line is 1-based
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// LineOptions controls how a set is read from and written to text with one item per line, such as an allow-list
+// file. Lines may end with LF or CRLF, and a UTF-8 byte order mark at the start is ignored. Blank lines are
+// skipped
+type LineOptions struct {
+	// CommentPrefix starts a comment line, which is skipped. Only whole lines are comments: the prefix may be
+	// preceded by whitespace, but not by an item. Defaults to DefaultLineCommentPrefix
+	CommentPrefix string
+	// NoComments reads every line as an item, even ones starting with CommentPrefix
+	NoComments bool
+	// KeepSpace keeps whitespace at the start and end of each line as part of the item. When false, it's trimmed
+	KeepSpace bool
+	// MaxLineLength is the longest line, in bytes, that is accepted. Longer lines fail with ErrLineTooLong instead
+	// of being buffered. Defaults to DefaultMaxLineLength
+	MaxLineLength int
+	// AllowDuplicates tolerates a value appearing on more than one line. When false, reading a repeated value fails
+	// with a *DuplicateValueError
+	AllowDuplicates bool
+}
+
+func (o LineOptions) commentPrefix() string {
+	if o.CommentPrefix == "" {
+		return DefaultLineCommentPrefix
+	}
+	return o.CommentPrefix
+}
+
+func (o LineOptions) maxLineLength() int {
+	if o.MaxLineLength <= 0 {
+		return DefaultMaxLineLength
+	}
+	return o.MaxLineLength
+}
+
+// item returns the item on line, or false if the line is blank or a comment
+func (o LineOptions) item(line string) (string, bool) {
+	if !o.KeepSpace {
+		line = strings.TrimSpace(line)
+	}
+	if line == "" {
+		return "", false
+	}
+	if !o.NoComments && strings.HasPrefix(strings.TrimLeftFunc(line, unicode.IsSpace), o.commentPrefix()) {
+		return "", false
+	}
+	return line, true
+}
+
+// checkWritable returns an error if item, written as a line, would not be read back as itself
+func (o LineOptions) checkWritable(item string, first bool) error {
+	reason := ""
+	switch {
+	case strings.ContainsAny(item, "\n\r"):
+		reason = "contains a line break"
+	case len(item) > o.maxLineLength():
+		reason = "is longer than MaxLineLength"
+	case first && strings.HasPrefix(item, byteOrderMark):
+		reason = "starts with a byte order mark"
+	default:
+		if read, ok := o.item(item); !ok || read != item {
+			reason = "would be read as blank, a comment or trimmed"
+		}
+	}
+	if reason == "" {
+		return nil
+	}
+	return fmt.Errorf("%w: %q %s", ErrUnwritableItem, item, reason)
+}
+
+// ReadFrom reads a new set from text with one item per line
+func ReadFrom(r io.Reader, opts LineOptions) (*T, error) {
+	out := New()
+	if _, err := DecodeLines(r, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DecodeLines adds the item on each line read from r to into, until r returns io.EOF. Returns the number of bytes
+// read from r. Errors about a particular line are returned in a *LineError. Duplicates are detected using into's
+// own comparison rules. into may have been partly filled when an error is returned
+func DecodeLines(r io.Reader, into Interface, opts LineOptions) (n int64, err error) {
+	counter := &countingReader{r: r}
+	maxLen := opts.maxLineLength()
+	scanner := bufio.NewScanner(counter)
+	// leave room for the line ending, so that a line of exactly maxLen is not too long for the scanner
+	scanner.Buffer(make([]byte, 0, min(maxLen+2, 4096)), maxLen+2)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if line == 1 {
+			text = strings.TrimPrefix(text, byteOrderMark)
+		}
+		if len(text) > maxLen {
+			return counter.n, &LineError{Line: line, Err: ErrLineTooLong}
+		}
+		item, ok := opts.item(text)
+		if !ok {
+			continue
+		}
+		if !opts.AllowDuplicates && into.Includes(item) {
+			return counter.n, &LineError{Line: line, Err: &DuplicateValueError{Value: item}}
+		}
+		into.Add(item)
+	}
+	if err = scanner.Err(); errors.Is(err, bufio.ErrTooLong) {
+		return counter.n, &LineError{Line: line + 1, Err: ErrLineTooLong}
+	}
+	return counter.n, err
+}
+
+// EncodeLines writes each item of s to w in lexical order, each followed by a line feed. Returns the number of
+// bytes written. Nothing is written if any item would not be read back as itself by DecodeLines with the same
+// options, in which case the error wraps ErrUnwritableItem
+func EncodeLines(w io.Writer, s Immutable, opts LineOptions) (n int64, err error) {
+	items := SortedSlice(s)
+	for i, item := range items {
+		if err = opts.checkWritable(item, i == 0); err != nil {
+			return 0, err
+		}
+	}
+	counter := &countingWriter{w: w}
+	buffered := bufio.NewWriter(counter)
+	for _, item := range items {
+		_, _ = buffered.WriteString(item)
+		_ = buffered.WriteByte('\n')
+	}
+	err = buffered.Flush()
+	return counter.n, err
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (n int, err error) {
+	n, err = c.r.Read(p)
+	c.n += int64(n)
+	return
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (n int, err error) {
+	n, err = c.w.Write(p)
+	c.n += int64(n)
+	return
+}
+
+// Lines reads and writes Set as text with one item per line, using Options. Use it in place of the set when the
+// defaults of T.ReadFrom and T.WriteTo do not suit. A nil Set is written as no lines, and read into a new *T
+type Lines struct {
+	Set     *T
+	Options LineOptions
+}
+
+// ReadFrom implements io.ReaderFrom, adding the item on each line read from r to the set. Repeated values within r
+// are rejected unless Options allows them, but values already in the set are not. The set is left unchanged if an
+// error is returned
+func (l *Lines) ReadFrom(r io.Reader) (n int64, err error) {
+	decoded := NewWithCapacity(0)
+	if n, err = DecodeLines(r, decoded, l.Options); err != nil {
+		return
+	}
+	if l.Set == nil {
+		l.Set = decoded
+		return
+	}
+	decoded.Each(l.Set.Add)
+	return
+}
+
+// WriteTo implements io.WriterTo, writing each item of the set on its own line in lexical order
+func (l Lines) WriteTo(w io.Writer) (n int64, err error) {
+	return EncodeLines(w, orEmpty(l.Set), l.Options)
+}
+
+// ReadFrom implements io.ReaderFrom, adding the item on each line read from r to the set, with the default
+// LineOptions. Repeated values within r are rejected, but values already in the set are not. A zero-value T may be
+// used. The set is left unchanged if an error is returned. Use Lines or the ReadFrom function to choose options
+func (c *T) ReadFrom(r io.Reader) (n int64, err error) {
+	return (&Lines{Set: c}).ReadFrom(r)
+}
+
+// WriteTo implements io.WriterTo, writing each item of the set on its own line in lexical order
+func (c *T) WriteTo(w io.Writer) (n int64, err error) {
+	return EncodeLines(w, c, LineOptions{})
+}
+
+// WriteTo implements io.WriterTo, writing each item of the snapshot on its own line in lexical order
+func (f *Frozen) WriteTo(w io.Writer) (n int64, err error) {
+	return EncodeLines(w, f, LineOptions{})
+}
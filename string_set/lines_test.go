@@ -0,0 +1,220 @@
+package string_set
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+)
+
+var _ io.ReaderFrom = &T{}
+var _ io.WriterTo = &T{}
+
+func TestReadFrom(t *testing.T) {
+	cases := map[string]struct {
+		input       string
+		opts        LineOptions
+		expected    []string
+		expectedErr error
+	}{
+		"empty": {
+			input: "",
+		},
+		"items": {
+			input:    "b\na\nc",
+			expected: []string{"a", "b", "c"},
+		},
+		"comments and blank lines": {
+			input:    "# allow-list\n\na\n  # indented comment\n   \nb\n",
+			expected: []string{"a", "b"},
+		},
+		"comment prefix": {
+			input:    "// comment\n#a\n",
+			opts:     LineOptions{CommentPrefix: "//"},
+			expected: []string{"#a"},
+		},
+		"no comments": {
+			input:    "#a\nb\n",
+			opts:     LineOptions{NoComments: true},
+			expected: []string{"#a", "b"},
+		},
+		"trimmed": {
+			input:    "  a \t\n\tb\n",
+			expected: []string{"a", "b"},
+		},
+		"keep space": {
+			input:    "  a \nb\n",
+			opts:     LineOptions{KeepSpace: true},
+			expected: []string{"  a ", "b"},
+		},
+		"crlf": {
+			input:    "a\r\nb\r\n",
+			opts:     LineOptions{KeepSpace: true},
+			expected: []string{"a", "b"},
+		},
+		"byte order mark": {
+			input:    "\uFEFFa\nb\n",
+			opts:     LineOptions{KeepSpace: true},
+			expected: []string{"a", "b"},
+		},
+		"line at max length": {
+			input:    "abcd\r\nef\n",
+			opts:     LineOptions{MaxLineLength: 4},
+			expected: []string{"abcd", "ef"},
+		},
+		"line too long": {
+			input:       "abcd\nefghi\n",
+			opts:        LineOptions{MaxLineLength: 4},
+			expectedErr: &LineError{Line: 2, Err: ErrLineTooLong},
+		},
+		"line too long for buffer": {
+			input:       "a\n" + strings.Repeat("b", DefaultMaxLineLength*2),
+			expectedErr: &LineError{Line: 2, Err: ErrLineTooLong},
+		},
+		"duplicates rejected": {
+			input:       "a\n# comment\nb\n a\n",
+			expectedErr: &LineError{Line: 4, Err: &DuplicateValueError{Value: "a"}},
+		},
+		"duplicates allowed": {
+			input:    "a\nb\na\n",
+			opts:     LineOptions{AllowDuplicates: true},
+			expected: []string{"a", "b"},
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			actual, err := ReadFrom(strings.NewReader(c.input), c.opts)
+			if c.expectedErr != nil {
+				assert.Equal(t, c.expectedErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, NewOf(c.expected...).IsEqualTo(actual))
+		})
+	}
+}
+
+func TestReadFrom_ReaderError(t *testing.T) {
+	failure := errors.New("disk on fire")
+	_, err := ReadFrom(io.MultiReader(strings.NewReader("a\n"), &failingReader{err: failure}), LineOptions{})
+	assert.Equal(t, failure, err)
+}
+
+type failingReader struct {
+	err error
+}
+
+func (f *failingReader) Read([]byte) (int, error) {
+	return 0, f.err
+}
+
+func TestCollection_ReadFrom(t *testing.T) {
+	set := NewOf("a")
+	n, err := set.ReadFrom(strings.NewReader("a\nb\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), n)
+	assert.True(t, NewOf("a", "b").IsEqualTo(set))
+
+	_, err = set.ReadFrom(strings.NewReader("c\nc\n"))
+	assert.Error(t, err)
+	assert.True(t, NewOf("a", "b").IsEqualTo(set))
+
+	zero := &T{}
+	_, err = (&Lines{Set: zero, Options: LineOptions{NoComments: true}}).ReadFrom(strings.NewReader("#a\n"))
+	assert.NoError(t, err)
+	assert.True(t, NewOf("#a").IsEqualTo(zero))
+
+	var lines Lines
+	_, err = lines.ReadFrom(strings.NewReader("a\n"))
+	assert.NoError(t, err)
+	assert.True(t, NewOf("a").IsEqualTo(lines.Set))
+}
+
+func TestCollection_WriteTo(t *testing.T) {
+	cases := map[string]struct {
+		input    *T
+		opts     LineOptions
+		expected string
+		invalid  bool
+	}{
+		"empty": {
+			input: New(),
+		},
+		"zero value": {
+			input: &T{},
+		},
+		"sorted": {
+			input:    NewOf("c", "a", "b"),
+			expected: "a\nb\nc\n",
+		},
+		"line break": {
+			input:   NewOf("a\nb"),
+			invalid: true,
+		},
+		"carriage return": {
+			input:   NewOf("a\r"),
+			invalid: true,
+		},
+		"empty string": {
+			input:   NewOf("", "a"),
+			invalid: true,
+		},
+		"comment": {
+			input:   NewOf("#a"),
+			invalid: true,
+		},
+		"comment without comments": {
+			input:    NewOf("#a"),
+			opts:     LineOptions{NoComments: true},
+			expected: "#a\n",
+		},
+		"space": {
+			input:   NewOf(" a"),
+			invalid: true,
+		},
+		"space kept": {
+			input:    NewOf(" a"),
+			opts:     LineOptions{KeepSpace: true},
+			expected: " a\n",
+		},
+		"too long": {
+			input:   NewOf("abcde"),
+			opts:    LineOptions{MaxLineLength: 4},
+			invalid: true,
+		},
+		"byte order mark": {
+			input:   NewOf("\uFEFFa"),
+			opts:    LineOptions{KeepSpace: true},
+			invalid: true,
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			actual := bytes.Buffer{}
+			n, err := Lines{Set: c.input, Options: c.opts}.WriteTo(&actual)
+			if c.invalid {
+				assert.ErrorIs(t, err, ErrUnwritableItem)
+				assert.Equal(t, 0, actual.Len())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, actual.String())
+			assert.Equal(t, int64(len(c.expected)), n)
+		})
+	}
+}
+
+func TestCollection_LinesRoundTrip(t *testing.T) {
+	set := NewOf("example.com", "example.org", "a b", "日本")
+	actual := bytes.Buffer{}
+	_, err := Lines{Set: set, Options: LineOptions{CommentPrefix: ";"}}.WriteTo(&actual)
+	assert.NoError(t, err)
+
+	read, err := ReadFrom(&actual, LineOptions{CommentPrefix: ";"})
+	assert.NoError(t, err)
+	assert.True(t, set.IsEqualTo(read))
+}
@@ -0,0 +1,128 @@
+package string_set
+
+import (
+	"slices"
+)
+
+// UnionAll returns a new set containing all of the items of sets. It is equivalent to chaining Union over sets from
+// left to right, so the result is of the same type as the first set and items are compared as CompareKey describes,
+// but only one set is created. Returns an empty *T if sets is empty
+func UnionAll(sets ...Immutable) (out Interface) {
+	if len(sets) == 0 {
+		return New()
+	}
+	out = sets[0].Copy()
+	for _, s := range sets[1:] {
+		out.UnionWith(s)
+	}
+	return
+}
+
+// IntersectAll returns a new set containing only the items common to all of sets, as compared by CompareKey. It
+// starts from the smallest set and intersects it with the others from smallest to largest, stopping as soon as the
+// result is empty, so the result is of the same type as the smallest set. Returns an empty *T if sets is empty
+func IntersectAll(sets ...Immutable) (out Interface) {
+	if len(sets) == 0 {
+		return New()
+	}
+	bySize := slices.Clone(sets)
+	slices.SortStableFunc(bySize, func(a, b Immutable) int {
+		return a.Len() - b.Len()
+	})
+	out = bySize[0].Copy()
+	for _, s := range bySize[1:] {
+		if out.IsEmpty() {
+			break
+		}
+		out.IntersectWith(s)
+	}
+	return
+}
+
+// CountOccurrences returns, for each string in any of sets, the number of sets that contain it. When any of sets
+// normalizes, strings are compared by the normalizations of all of sets, as CompareKey does for two sets, and each is
+// counted under the spelling it first appears with
+func CountOccurrences(sets ...Immutable) (counts map[string]int) {
+	counts = make(map[string]int)
+	key := compareKeyOfAll(sets)
+	if key == nil {
+		for _, s := range sets {
+			s.Each(func(v string) {
+				counts[v]++
+			})
+		}
+		return
+	}
+	spellings := make(map[string]string)
+	for _, s := range sets {
+		// a set may hold several spellings of one key, which only count once
+		seen := New()
+		s.Each(func(v string) {
+			k := key(v)
+			if seen.Includes(k) {
+				return
+			}
+			seen.Add(k)
+			if _, ok := spellings[k]; !ok {
+				spellings[k] = v
+			}
+			counts[spellings[k]]++
+		})
+	}
+	return
+}
+
+// InAtLeast returns a new set containing the strings that are in at least k of sets, counted and spelled as
+// CountOccurrences does. InAtLeast(1, sets...) is the union and InAtLeast(len(sets), sets...) is the intersection
+func InAtLeast(k int, sets ...Immutable) *T {
+	out := New()
+	if k > len(sets) {
+		return out
+	}
+	for v, count := range CountOccurrences(sets...) {
+		if count >= k {
+			out.Add(v)
+		}
+	}
+	return out
+}
+
+// IncludedInAtLeast returns true if at least k of sets include v. When any of sets normalizes, v is compared by the
+// normalizations of all of sets, as CountOccurrences does. Stops as soon as the answer is known
+func IncludedInAtLeast(v string, k int, sets ...Immutable) bool {
+	key := compareKeyOfAll(sets)
+	for i, s := range sets {
+		if k <= 0 || k > len(sets)-i {
+			break
+		}
+		if s.Includes(v) || key != nil && anyOf(s, func(item string) bool {
+			return key(item) == key(v)
+		}, true) {
+			k--
+		}
+	}
+	return k <= 0
+}
+
+// compareKeyOfAll returns the function that maps strings to the form they are compared in when all of sets are
+// combined: the normalization of each set that normalizes, in turn. Returns nil when none of sets normalizes
+func compareKeyOfAll(sets []Immutable) func(v string) string {
+	var normalizers []func(v string) string
+	for _, s := range sets {
+		if n := normalizerOf(s); n != nil {
+			normalizers = append(normalizers, n)
+		}
+	}
+	switch len(normalizers) {
+	case 0:
+		return nil
+	case 1:
+		return normalizers[0]
+	}
+	return func(v string) string {
+		for _, n := range normalizers {
+			v = n(v)
+		}
+		return v
+	}
+}
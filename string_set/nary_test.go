@@ -0,0 +1,183 @@
+package string_set
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestUnionAll(t *testing.T) {
+	cases := map[string]struct {
+		sets     []Immutable
+		expected []string
+	}{
+		"none": {
+			expected: []string{},
+		},
+		"one": {
+			sets:     []Immutable{NewOf("a", "b")},
+			expected: []string{"a", "b"},
+		},
+		"many": {
+			sets:     []Immutable{NewOf("a"), NewOf("a", "b").Freeze(), Empty, NewOf("c")},
+			expected: []string{"a", "b", "c"},
+		},
+		"normalized first": {
+			sets:     []Immutable{NewNormalizedOf(strings.ToLower, "a"), NewOf("A", "b"), NewOf("B")},
+			expected: []string{"a", "b"},
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			actual := UnionAll(c.sets...)
+			assert.ElementsMatch(t, c.expected, actual.ToSlice())
+			if len(c.sets) > 0 {
+				assert.IsType(t, c.sets[0].Copy(), actual)
+			}
+		})
+	}
+}
+
+func TestUnionAll_DoesNotAlterInputs(t *testing.T) {
+	first := NewOf("a")
+	UnionAll(first, NewOf("b")).Add("c")
+	assert.ElementsMatch(t, []string{"a"}, first.ToSlice())
+}
+
+func TestIntersectAll(t *testing.T) {
+	cases := map[string]struct {
+		sets     []Immutable
+		expected []string
+	}{
+		"none": {
+			expected: []string{},
+		},
+		"one": {
+			sets:     []Immutable{NewOf("a", "b")},
+			expected: []string{"a", "b"},
+		},
+		"many": {
+			sets:     []Immutable{NewOf("a", "b", "c"), NewOf("b", "c").Freeze(), NewOf("c", "b", "d")},
+			expected: []string{"b", "c"},
+		},
+		"empty operand": {
+			sets:     []Immutable{NewOf("a", "b", "c"), Empty, NewOf("a")},
+			expected: []string{},
+		},
+		"smallest set is normalized": {
+			sets:     []Immutable{NewOf("a", "A", "b"), NewNormalizedOf(strings.ToLower, "A")},
+			expected: []string{"a"},
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			assert.ElementsMatch(t, c.expected, IntersectAll(c.sets...).ToSlice())
+		})
+	}
+}
+
+func TestCountOccurrences(t *testing.T) {
+	actual := CountOccurrences(NewOf("a", "b"), NewOf("b", "c"), NewOf("b"), Empty)
+	assert.Equal(t, map[string]int{"a": 1, "b": 3, "c": 1}, actual)
+	assert.Equal(t, map[string]int{}, CountOccurrences())
+}
+
+func TestInAtLeast(t *testing.T) {
+	sets := []Immutable{NewOf("a", "b", "c"), NewOf("b", "c"), NewOf("c", "d")}
+	cases := map[string]struct {
+		k        int
+		expected []string
+	}{
+		"zero": {
+			k:        0,
+			expected: []string{"a", "b", "c", "d"},
+		},
+		"one is the union": {
+			k:        1,
+			expected: []string{"a", "b", "c", "d"},
+		},
+		"two": {
+			k:        2,
+			expected: []string{"b", "c"},
+		},
+		"all is the intersection": {
+			k:        3,
+			expected: []string{"c"},
+		},
+		"more than there are sets": {
+			k:        4,
+			expected: []string{},
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			assert.ElementsMatch(t, c.expected, InAtLeast(c.k, sets...).ToSlice())
+			for _, v := range []string{"a", "b", "c", "d", "e"} {
+				assert.Equal(t, InAtLeast(c.k, sets...).Includes(v) || c.k <= 0, IncludedInAtLeast(v, c.k, sets...), v)
+			}
+		})
+	}
+}
+
+func TestCountOccurrences_UsesCompareKey(t *testing.T) {
+	sets := []Immutable{NewOf("A", "a", "b"), NewNormalizedOf(strings.ToLower, "B"), NewOf("a ", "c")}
+	assert.Equal(t, map[string]int{"A": 1, "b": 2, "a ": 1, "c": 1}, CountOccurrences(sets...))
+	assert.ElementsMatch(t, []string{"b"}, InAtLeast(2, sets...).ToSlice())
+
+	trimmed := append(sets, NewNormalizedOf(strings.TrimSpace))
+	assert.Equal(t, map[string]int{"A": 2, "b": 2, "c": 1}, CountOccurrences(trimmed...))
+	assert.ElementsMatch(t, []string{"A", "b"}, InAtLeast(2, trimmed...).ToSlice())
+}
+
+func TestIncludedInAtLeast_UsesCompareKey(t *testing.T) {
+	sets := []Immutable{NewOf("a"), NewNormalizedOf(strings.ToLower, "A"), NewOf("A")}
+	assert.True(t, IncludedInAtLeast("a", 3, sets...))
+	assert.True(t, IncludedInAtLeast("A", 3, sets...))
+	assert.False(t, IncludedInAtLeast("b", 1, sets...))
+	assert.False(t, IncludedInAtLeast("a", 2, NewOf("a"), NewOf("A")))
+}
+
+func BenchmarkUnionAll_Chained(b *testing.B) {
+	sets := foldedSets()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out := sets[0]
+		for _, set := range sets[1:] {
+			out = out.Union(set)
+		}
+	}
+}
+
+func BenchmarkUnionAll(b *testing.B) {
+	sets := foldedSets()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		UnionAll(sets...)
+	}
+}
+
+func BenchmarkIntersectAll_Chained(b *testing.B) {
+	sets := foldedSets()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out := sets[0]
+		for _, set := range sets[1:] {
+			out = out.Intersection(set)
+		}
+	}
+}
+
+func BenchmarkIntersectAll(b *testing.B) {
+	sets := foldedSets()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		IntersectAll(sets...)
+	}
+}
@@ -0,0 +1,205 @@
+package string_set
+
+import (
+	"iter"
+)
+
+// NewNormalized creates a new, empty, string set that stores and compares strings after passing them through
+// normalize. normalize should be idempotent and must be safe for concurrent use.
+//
+// Operations that combine a Normalized set with a set that normalizes differently follow the rules of CompareKey
+func NewNormalized(normalize func(v string) string) *Normalized {
+	return NewNormalizedWithCapacity(normalize, defaultCapacity)
+}
+
+// NewNormalizedOf is a convenience method to create a normalized string set containing the items you specify
+func NewNormalizedOf(normalize func(v string) string, items ...string) *Normalized {
+	ret := NewNormalizedWithCapacity(normalize, len(items))
+	ret.AddMany(items...)
+	return ret
+}
+
+// NewNormalizedWithCapacity creates a new, empty, normalized string set with the provided capacity
+func NewNormalizedWithCapacity(normalize func(v string) string, capacity int) *Normalized {
+	return &Normalized{
+		items:     NewWithCapacity(capacity),
+		normalize: normalize,
+	}
+}
+
+// Normalized holds a set of strings that have been passed through a normalizing function, do not instantiate this
+// yourself, Please use NewNormalized, NewNormalizedOf, or NewNormalizedWithCapacity
+type Normalized struct {
+	items     *T
+	normalize func(v string) string
+}
+
+// Normalize returns the form of v that is stored in the set
+func (c *Normalized) Normalize(v string) string {
+	return c.normalize(v)
+}
+
+func (c *Normalized) Add(v string) {
+	c.items.Add(c.normalize(v))
+}
+
+func (c *Normalized) AddMany(v ...string) {
+	for _, s := range v {
+		c.Add(s)
+	}
+}
+
+func (c *Normalized) Remove(v string) {
+	c.items.Remove(c.normalize(v))
+}
+
+func (c *Normalized) RemoveMany(v ...string) {
+	for _, s := range v {
+		c.Remove(s)
+	}
+}
+
+// UnionWith adds the items of o that the set does not already contain, as compared by CompareKey. Returns the
+// number of items added
+func (c *Normalized) UnionWith(o Immutable) (changed int) {
+	return UnionWith(c, o)
+}
+
+// SubtractWith removes the items that o contains, as compared by CompareKey. Returns the number of items removed
+func (c *Normalized) SubtractWith(o Immutable) (changed int) {
+	return SubtractWith(c, o)
+}
+
+// IntersectWith removes the items that o does not contain, as compared by CompareKey. Returns the number of
+// items removed
+func (c *Normalized) IntersectWith(o Immutable) (changed int) {
+	return IntersectWith(c, o)
+}
+
+// SymmetricDifferenceWith removes the items that o contains, and adds the items of o that the set did not contain,
+// as compared by CompareKey. Returns the number of items added or removed
+func (c *Normalized) SymmetricDifferenceWith(o Immutable) (changed int) {
+	return SymmetricDifferenceWith(c, o)
+}
+
+// Apply removes the items d removed and adds the items d added, as SubtractWith and UnionWith do. Returns the number
+// of items removed or added
+func (c *Normalized) Apply(d DiffResult) (changed int) {
+	return c.SubtractWith(d.Removed) + c.UnionWith(d.Added)
+}
+
+func (c *Normalized) Includes(v string) bool {
+	return c.items.Includes(c.normalize(v))
+}
+
+func (c *Normalized) IsEmpty() bool {
+	return c.items.IsEmpty()
+}
+
+func (c *Normalized) Len() int {
+	return c.items.Len()
+}
+
+// IsEqualTo returns true if both sets contain the same strings, as compared by CompareKey
+func (c *Normalized) IsEqualTo(o Immutable) bool {
+	return IsEqual(c, o)
+}
+
+// Union returns a new normalized set containing all of the items from the callee and the items of o that, as
+// compared by CompareKey, are not already in the callee
+func (c *Normalized) Union(o Immutable) (out Interface) {
+	out = c.Copy()
+	UnionInto(out, c, o)
+	return
+}
+
+// Subtract returns a new normalized set containing the items of the callee that, as compared by CompareKey, are not
+// in o
+func (c *Normalized) Subtract(o Immutable) (out Interface) {
+	out = NewNormalizedWithCapacity(c.normalize, c.Len())
+	SubtractInto(out, c, o)
+	return
+}
+
+// Intersection returns a new normalized set containing the items of the callee that, as compared by CompareKey, are
+// also in o
+func (c *Normalized) Intersection(o Immutable) (out Interface) {
+	out = NewNormalizedWithCapacity(c.normalize, c.Len())
+	IntersectionInto(out, c, o)
+	return
+}
+
+// SymmetricDifference returns a new normalized set containing the items of either the callee or the parameter that are
+// not in both, as compared by CompareKey
+func (c *Normalized) SymmetricDifference(o Immutable) (out Interface) {
+	out = NewNormalizedWithCapacity(c.normalize, c.Len())
+	SymmetricDifferenceInto(out, c, o)
+	return
+}
+
+// IsSubsetOf returns true if o contains every item of the set, as compared by CompareKey
+func (c *Normalized) IsSubsetOf(o Immutable) bool {
+	return IsSubset(c, o)
+}
+
+// IsProperSubsetOf returns true if o contains every item of the set and has items the set does not, as compared
+// by CompareKey
+func (c *Normalized) IsProperSubsetOf(o Immutable) bool {
+	return IsProperSubset(c, o)
+}
+
+// IsSupersetOf returns true if the set contains every item of o, as compared by CompareKey
+func (c *Normalized) IsSupersetOf(o Immutable) bool {
+	return IsSubset(o, c)
+}
+
+// IsDisjointFrom returns true if the set and o have no items in common, as compared by CompareKey
+func (c *Normalized) IsDisjointFrom(o Immutable) bool {
+	return IsDisjoint(c, o)
+}
+
+// IntersectionLen returns the number of items Intersection would return, without creating the intersection
+func (c *Normalized) IntersectionLen(o Immutable) int {
+	return IntersectionLen(c, o)
+}
+
+// ToSlice returns the normalized items in the set, in no particular order
+func (c *Normalized) ToSlice() []string {
+	return c.items.ToSlice()
+}
+
+// Each loops over each normalized item in the set. The order is not guaranteed
+func (c *Normalized) Each(item func(v string)) {
+	c.items.Each(item)
+}
+
+func (c *Normalized) EachCancelable(item func(v string) (next NextAction)) {
+	c.items.EachCancelable(item)
+}
+
+func (c *Normalized) All() iter.Seq[string] {
+	return c.items.All()
+}
+
+// Sorted returns an iterator over the normalized items in the set in lexical order
+func (c *Normalized) Sorted() iter.Seq[string] {
+	return c.items.Sorted()
+}
+
+// Any returns true if predicate returns true for any normalized item. See T.Any
+func (c *Normalized) Any(item func(v string) (didMatch bool)) bool {
+	return c.items.Any(item)
+}
+
+// None returns true if predicate returned false for every normalized item. See T.None
+func (c *Normalized) None(item func(v string) (didMatch bool)) bool {
+	return c.items.None(item)
+}
+
+// Copy returns a shared-nothing copy of the set that uses the same normalizing function
+func (c *Normalized) Copy() Interface {
+	return &Normalized{
+		items:     c.items.Copy().(*T),
+		normalize: c.normalize,
+	}
+}
@@ -0,0 +1,108 @@
+package string_set
+
+import (
+	"github.com/stretchr/testify/assert"
+	"path"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func trimDot(v string) string {
+	return strings.TrimSuffix(v, ".")
+}
+
+func TestNormalized_Add(t *testing.T) {
+	cases := map[string]struct {
+		normalize func(v string) string
+		input     []string
+		expected  []string
+	}{
+		"hostnames": {
+			normalize: trimDot,
+			input:     []string{"example.com.", "example.com", "example.org"},
+			expected:  []string{"example.com", "example.org"},
+		},
+		"paths": {
+			normalize: path.Clean,
+			input:     []string{"/a/b/../c", "/a/c/", "/a//c"},
+			expected:  []string{"/a/c"},
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			set := NewNormalizedOf(c.normalize, c.input...)
+			assert.Equal(t, c.expected, slices.Collect(set.Sorted()))
+			for _, v := range c.input {
+				assert.True(t, set.Includes(v))
+			}
+			set.RemoveMany(c.input...)
+			assert.True(t, set.IsEmpty())
+		})
+	}
+}
+
+func TestNormalized_MixedNormalizers(t *testing.T) {
+	cases := map[string]struct {
+		a            Immutable
+		b            Immutable
+		equal        bool
+		union        []string
+		subtract     []string
+		intersection []string
+	}{
+		"same normalizer": {
+			a:            NewNormalizedOf(strings.ToLower, "A", "b"),
+			b:            NewNormalizedOf(strings.ToLower, "a", "C"),
+			union:        []string{"a", "b", "c"},
+			subtract:     []string{"b"},
+			intersection: []string{"a"},
+		},
+		"different normalizers": {
+			a:            NewNormalizedOf(strings.ToLower, "Example.COM", "b"),
+			b:            NewNormalizedOf(trimDot, "example.com.", "c"),
+			union:        []string{"b", "c", "example.com"},
+			subtract:     []string{"b"},
+			intersection: []string{"example.com"},
+		},
+		"different normalizers equal": {
+			a:            NewNormalizedOf(strings.ToLower, "Example.COM"),
+			b:            NewNormalizedOf(trimDot, "example.com."),
+			equal:        true,
+			union:        []string{"example.com"},
+			intersection: []string{"example.com"},
+		},
+		"case-sensitive operand": {
+			a:            NewNormalizedOf(strings.ToLower, "a", "b"),
+			b:            NewOf("A", "B"),
+			equal:        true,
+			union:        []string{"a", "b"},
+			intersection: []string{"a", "b"},
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			assert.Equal(t, c.equal, c.a.IsEqualTo(c.b))
+			assert.Equal(t, c.union, sortedOf(c.a.Union(c.b)))
+			assert.Equal(t, c.subtract, sortedOf(c.a.Subtract(c.b)))
+			assert.Equal(t, c.intersection, sortedOf(c.a.Intersection(c.b)))
+		})
+	}
+}
+
+func TestNormalized_Copy(t *testing.T) {
+	set := NewNormalizedOf(strings.ToLower, "A")
+	actual := set.Copy()
+	actual.Add("B")
+	assert.True(t, actual.Includes("b"))
+	assert.Equal(t, 1, set.Len())
+	assert.True(t, set.Any(func(v string) bool { return v == "a" }))
+	assert.True(t, set.None(func(v string) bool { return v == "A" }))
+}
+
+// sortedOf returns the items of s in lexical order, or nil if s is empty
+func sortedOf(s Immutable) []string {
+	return slices.Sorted(s.All())
+}
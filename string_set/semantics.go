@@ -0,0 +1,284 @@
+package string_set
+
+// Normalizer is implemented by sets that store and compare strings in a normalized form instead of byte-for-byte,
+// such as case-insensitive sets. Sets that do not implement it compare strings byte-for-byte
+type Normalizer interface {
+	// Normalize returns the form of v that the set stores and compares
+	Normalize(v string) string
+}
+
+// CompareKey returns the function that maps strings to the form they are compared in when left is combined with o:
+// o's normalization followed by left's. Returns nil when neither set normalizes, meaning strings are compared as-is.
+//
+// Sets can disagree about when two strings are the same: T compares them byte-for-byte, a case-insensitive set
+// ignores case, and a Normalized set applies its own function. So that any set can be combined with any other and
+// get the same answer regardless of which side of the operation it is on, every set in this module follows these
+// rules:
+//
+//   - Two items are the same if they have the same key. The key applies the normalizations of both operands, so "A"
+//     in a case-sensitive set matches "a" in a case-insensitive set
+//   - IsEqualTo is true when both sets contain the same keys, so a.IsEqualTo(b) == b.IsEqualTo(a)
+//   - Union, Subtract, Intersection and SymmetricDifference return a set of the same type as the callee, which keeps
+//     the callee's way of comparing strings. Items that are in both operands are taken from the callee
+//   - IsSubsetOf, IsProperSubsetOf, IsSupersetOf and IsDisjointFrom compare keys, so a.IsSubsetOf(b) ==
+//     b.IsSupersetOf(a). IntersectionLen is the Len of the set Intersection would return
+//
+// a.Union(b) and b.Union(a) may therefore differ in type and spelling, but they are IsEqualTo each other, and
+// likewise for Intersection. This holds as long as the operands' normalizations commute, which is true of those in
+// this module, such as case folding and trimming white space. Union and Intersection are only associative when all
+// operands compare strings the same way, as the intermediate result takes the comparison of its callee
+func CompareKey(left, o Immutable) func(v string) string {
+	leftNormalize, oNormalize := normalizerOf(left), normalizerOf(o)
+	switch {
+	case leftNormalize == nil:
+		return oNormalize
+	case oNormalize == nil:
+		return leftNormalize
+	}
+	return func(v string) string {
+		return leftNormalize(oNormalize(v))
+	}
+}
+
+// KeysOf returns the keys of the items in s, as mapped by key. If key is nil, the items are copied as-is
+func KeysOf(s Immutable, key func(v string) string) *T {
+	out := NewWithCapacity(s.Len())
+	s.Each(func(v string) {
+		if key != nil {
+			v = key(v)
+		}
+		out.Add(v)
+	})
+	return out
+}
+
+// IsEqual returns true if left and o contain the same items, as compared by CompareKey. It is the IsEqualTo of
+// every set in this module
+func IsEqual(left, o Immutable) bool {
+	key := CompareKey(left, o)
+	if key == nil {
+		return isEqualAsIs(left, o)
+	}
+	return isEqualAsIs(KeysOf(left, key), KeysOf(o, key))
+}
+
+// UnionInto adds each item of o to out, unless an item of left is the same, as compared by CompareKey.
+// out should start as a copy of left
+func UnionInto(out Mutable, left, o Immutable) {
+	key := CompareKey(left, o)
+	if key == nil {
+		o.Each(out.Add)
+		return
+	}
+	seen := KeysOf(left, key)
+	o.Each(func(v string) {
+		if k := key(v); !seen.Includes(k) {
+			seen.Add(k)
+			out.Add(v)
+		}
+	})
+}
+
+// SubtractInto adds each item of left to out, unless o contains the same item, as compared by CompareKey
+func SubtractInto(out Mutable, left, o Immutable) {
+	filterInto(out, left, o, false)
+}
+
+// IntersectionInto adds each item of left to out if o contains the same item, as compared by CompareKey
+func IntersectionInto(out Mutable, left, o Immutable) {
+	filterInto(out, left, o, true)
+}
+
+// SymmetricDifferenceInto adds each item of left that o does not contain, and each item of o that left does not
+// contain, to out, as compared by CompareKey
+func SymmetricDifferenceInto(out Mutable, left, o Immutable) {
+	SubtractInto(out, left, o)
+	key := CompareKey(left, o)
+	if key == nil {
+		o.Each(func(v string) {
+			if !left.Includes(v) {
+				out.Add(v)
+			}
+		})
+		return
+	}
+	seen := KeysOf(left, key)
+	o.Each(func(v string) {
+		if k := key(v); !seen.Includes(k) {
+			seen.Add(k)
+			out.Add(v)
+		}
+	})
+}
+
+// UnionWith adds each item of o to c, unless c already contains the same item, as compared by CompareKey. Returns the
+// number of items added. It is the UnionWith of the sets in this module that do not have a faster way
+func UnionWith(c Interface, o Immutable) (changed int) {
+	before := c.Len()
+	if normalizerOf(o) == nil {
+		// c compares strings the CompareKey way already
+		o.Each(c.Add)
+		return c.Len() - before
+	}
+	key := CompareKey(c, o)
+	seen := KeysOf(c, key)
+	o.Each(func(v string) {
+		if k := key(v); !seen.Includes(k) {
+			seen.Add(k)
+			c.Add(v)
+		}
+	})
+	return c.Len() - before
+}
+
+// SubtractWith removes each item of c that o contains, as compared by CompareKey. Returns the number of items
+// removed
+func SubtractWith(c Interface, o Immutable) (changed int) {
+	return removeWhere(c, includerOf(c, o), true)
+}
+
+// IntersectWith removes each item of c that o does not contain, as compared by CompareKey. Returns the number of
+// items removed
+func IntersectWith(c Interface, o Immutable) (changed int) {
+	return removeWhere(c, includerOf(c, o), false)
+}
+
+// SymmetricDifferenceWith removes each item of c that o contains, and adds each item of o that c did not contain, as
+// compared by CompareKey. Returns the number of items added or removed
+func SymmetricDifferenceWith(c Interface, o Immutable) (changed int) {
+	// both sides are read before c changes, so o may be c itself
+	var add []string
+	key := CompareKey(c, o)
+	if key == nil {
+		o.Each(func(v string) {
+			if !c.Includes(v) {
+				add = append(add, v)
+			}
+		})
+	} else {
+		seen := KeysOf(c, key)
+		o.Each(func(v string) {
+			if k := key(v); !seen.Includes(k) {
+				seen.Add(k)
+				add = append(add, v)
+			}
+		})
+	}
+	changed = removeWhere(c, includerOf(c, o), true)
+	before := c.Len()
+	c.AddMany(add...)
+	return changed + c.Len() - before
+}
+
+// removeWhere removes each item of c for which includes returns want. The items are found before any is removed, so
+// that sets which cannot be changed while they are iterated over, and includes functions that read c, are safe
+func removeWhere(c Interface, includes func(v string) bool, want bool) int {
+	var remove []string
+	c.Each(func(v string) {
+		if includes(v) == want {
+			remove = append(remove, v)
+		}
+	})
+	c.RemoveMany(remove...)
+	return len(remove)
+}
+
+// IntersectionLen returns the number of items of left that o contains, as compared by CompareKey. This is the Len of
+// the set left.Intersection(o) would return. When neither set normalizes, the smaller set is iterated over
+func IntersectionLen(left, o Immutable) (count int) {
+	if CompareKey(left, o) == nil && o.Len() < left.Len() {
+		left, o = o, left
+	}
+	includes := includerOf(left, o)
+	left.Each(func(v string) {
+		if includes(v) {
+			count++
+		}
+	})
+	return
+}
+
+// IsSubset returns true if o contains every item of left, as compared by CompareKey
+func IsSubset(left, o Immutable) bool {
+	// short-circuit test for speed: without normalization, a larger set cannot fit in a smaller one
+	if CompareKey(left, o) == nil && left.Len() > o.Len() {
+		return false
+	}
+	return !anyOf(left, includerOf(left, o), false)
+}
+
+// IsProperSubset returns true if o contains every item of left, and o has items left does not, as compared by
+// CompareKey
+func IsProperSubset(left, o Immutable) bool {
+	return IsSubset(left, o) && !IsEqual(left, o)
+}
+
+// IsDisjoint returns true if left and o have no items in common, as compared by CompareKey. When neither set
+// normalizes, the smaller set is iterated over
+func IsDisjoint(left, o Immutable) bool {
+	if CompareKey(left, o) == nil && o.Len() < left.Len() {
+		left, o = o, left
+	}
+	return !anyOf(left, includerOf(left, o), true)
+}
+
+// filterInto adds each item of left to out when whether o contains it equals keepIfIncluded
+func filterInto(out Mutable, left, o Immutable, keepIfIncluded bool) {
+	includes := includerOf(left, o)
+	left.Each(func(v string) {
+		if includes(v) == keepIfIncluded {
+			out.Add(v)
+		}
+	})
+}
+
+// anyOf returns true if includes returns want for any item of s, stopping at the first one that does
+func anyOf(s Immutable, includes func(v string) bool, want bool) (found bool) {
+	s.EachCancelable(func(v string) NextAction {
+		if includes(v) == want {
+			found = true
+			return Break
+		}
+		return Continue
+	})
+	return
+}
+
+// includerOf returns a function reporting whether o contains the same item as v, an item of left, as compared by
+// CompareKey. o's own Includes is used when it already compares the CompareKey way, which is whenever left does not
+// normalize; otherwise o's keys are collected first
+func includerOf(left, o Immutable) func(v string) bool {
+	if normalizerOf(left) == nil {
+		return o.Includes
+	}
+	key := CompareKey(left, o)
+	others := KeysOf(o, key)
+	return func(v string) bool {
+		return others.Includes(key(v))
+	}
+}
+
+// isEqualAsIs compares the items of left and o byte-for-byte
+func isEqualAsIs(left, o Immutable) (equal bool) {
+	// short-circuit test for speed
+	if left.Len() != o.Len() {
+		return false
+	}
+	equal = true
+	left.EachCancelable(func(v string) NextAction {
+		if !o.Includes(v) {
+			equal = false
+			return Break
+		}
+		return Continue
+	})
+	return
+}
+
+// normalizerOf returns the normalization s applies to strings, or nil if s compares strings byte-for-byte
+func normalizerOf(s Immutable) func(v string) string {
+	if n, ok := s.(Normalizer); ok {
+		return n.Normalize
+	}
+	return nil
+}
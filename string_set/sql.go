@@ -0,0 +1,347 @@
+package string_set
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// SQLEncoding selects how a set is stored in a database column
+type SQLEncoding int
+
+const (
+	// SQLPostgresArray stores the set as a Postgres array literal, such as {a,b,"c d"}, for text[] columns
+	SQLPostgresArray SQLEncoding = iota
+	// SQLJSONArray stores the set as a JSON array, for json, jsonb or text columns
+	SQLJSONArray
+	// SQLDelimited stores the items joined by SQLOptions.Delimiter, for text columns. Delimiters and backslashes in
+	// items are escaped with a backslash. An empty column is an empty set, so a set containing only the empty
+	// string cannot be stored
+	SQLDelimited
+)
+
+func (e SQLEncoding) String() string {
+	switch e {
+	case SQLPostgresArray:
+		return "Postgres array"
+	case SQLJSONArray:
+		return "JSON array"
+	case SQLDelimited:
+		return "delimited text"
+	}
+	return fmt.Sprintf("SQLEncoding(%d)", int(e))
+}
+
+// DefaultSQLDelimiter separates items in SQLDelimited when SQLOptions.Delimiter is not set
+const DefaultSQLDelimiter = ','
+
+// SQLOptions controls how a set is stored in and read from a database column
+type SQLOptions struct {
+	// Encoding of the column. Defaults to SQLPostgresArray
+	Encoding SQLEncoding
+	// Delimiter separates items in SQLDelimited. Defaults to DefaultSQLDelimiter. Must not be a backslash
+	Delimiter rune
+	// AllowDuplicates tolerates a value appearing more than once in the column. When false, scanning a repeated
+	// value fails with a *DuplicateValueError
+	AllowDuplicates bool
+}
+
+func (o SQLOptions) delimiter() rune {
+	if o.Delimiter == 0 {
+		return DefaultSQLDelimiter
+	}
+	return o.Delimiter
+}
+
+// ErrInvalidSQLValue is returned, wrapped with the reason, when a column cannot be decoded as a set
+var ErrInvalidSQLValue = errors.New("string_set: invalid SQL value")
+
+// EncodeSQL returns the contents of s, in lexical order, as a string in the encoding opts selects
+func EncodeSQL(s Immutable, opts SQLOptions) (string, error) {
+	switch opts.Encoding {
+	case SQLPostgresArray:
+		return encodePostgresArray(SortedSlice(s)), nil
+	case SQLJSONArray:
+		out, err := EncodeJSON(s)
+		return string(out), err
+	case SQLDelimited:
+		if opts.delimiter() == '\\' {
+			return "", fmt.Errorf("%w: backslash cannot be the delimiter", ErrInvalidSQLValue)
+		}
+		items := SortedSlice(s)
+		if len(items) == 1 && items[0] == "" {
+			return "", fmt.Errorf("%w: a set containing only the empty string cannot be stored as delimited text", ErrInvalidSQLValue)
+		}
+		return encodeDelimited(items, opts.delimiter()), nil
+	}
+	return "", fmt.Errorf("%w: unknown encoding %v", ErrInvalidSQLValue, opts.Encoding)
+}
+
+// DecodeSQL adds each item of a column value, as passed to sql.Scanner.Scan, to into. NULL adds nothing.
+// Duplicates are detected using into's own comparison rules
+func DecodeSQL(src interface{}, into Interface, opts SQLOptions) error {
+	var value string
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return fmt.Errorf("%w: cannot scan %T into a set", ErrInvalidSQLValue, src)
+	}
+
+	var items []string
+	var err error
+	switch opts.Encoding {
+	case SQLPostgresArray:
+		items, err = decodePostgresArray(value)
+	case SQLJSONArray:
+		return DecodeJSON([]byte(value), into, JSONOptions{AllowDuplicates: opts.AllowDuplicates})
+	case SQLDelimited:
+		if opts.delimiter() == '\\' {
+			return fmt.Errorf("%w: backslash cannot be the delimiter", ErrInvalidSQLValue)
+		}
+		items, err = decodeDelimited(value, opts.delimiter())
+	default:
+		err = fmt.Errorf("%w: unknown encoding %v", ErrInvalidSQLValue, opts.Encoding)
+	}
+	if err != nil {
+		return err
+	}
+	return addDecoded(into, items, opts.AllowDuplicates)
+}
+
+func encodePostgresArray(items []string) string {
+	out := strings.Builder{}
+	out.WriteByte('{')
+	for i, item := range items {
+		if i > 0 {
+			out.WriteByte(',')
+		}
+		if !postgresNeedsQuotes(item) {
+			out.WriteString(item)
+			continue
+		}
+		out.WriteByte('"')
+		for _, r := range item {
+			if r == '"' || r == '\\' {
+				out.WriteByte('\\')
+			}
+			out.WriteRune(r)
+		}
+		out.WriteByte('"')
+	}
+	out.WriteByte('}')
+	return out.String()
+}
+
+// postgresNeedsQuotes returns true if item would not be read back as itself without quotes
+func postgresNeedsQuotes(item string) bool {
+	if item == "" || strings.EqualFold(item, "NULL") {
+		return true
+	}
+	return strings.ContainsFunc(item, func(r rune) bool {
+		return strings.ContainsRune(`{}",\`, r) || unicode.IsSpace(r)
+	})
+}
+
+// decodePostgresArray parses a one-dimensional Postgres array literal, such as {a,"b c"} or [1:2]={a,b}
+func decodePostgresArray(value string) (items []string, err error) {
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %s in Postgres array %q", ErrInvalidSQLValue, reason, value)
+	}
+	rest := strings.TrimSpace(value)
+	if strings.HasPrefix(rest, "[") {
+		// bounds decoration, written when the array does not start at 1
+		end := strings.Index(rest, "=")
+		if end < 0 {
+			return nil, invalid("unterminated bounds")
+		}
+		rest = strings.TrimSpace(rest[end+1:])
+	}
+	if !strings.HasPrefix(rest, "{") || !strings.HasSuffix(rest, "}") {
+		return nil, invalid("missing braces")
+	}
+	rest = rest[1 : len(rest)-1]
+	if strings.TrimSpace(rest) == "" {
+		return nil, nil
+	}
+	for {
+		var item string
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		switch {
+		case strings.HasPrefix(rest, "{"):
+			return nil, invalid("nested array")
+		case strings.HasPrefix(rest, `"`):
+			var ok bool
+			if item, rest, ok = readQuoted(rest[1:]); !ok {
+				return nil, invalid("unterminated quotes")
+			}
+			rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		default:
+			var unquoted string
+			unquoted, rest = readUnquoted(rest)
+			unquoted = strings.TrimRightFunc(unquoted, unicode.IsSpace)
+			if unquoted == "" {
+				return nil, invalid("empty item")
+			}
+			if strings.EqualFold(unquoted, "NULL") {
+				return nil, invalid("NULL item")
+			}
+			var ok bool
+			if item, ok = unescape(unquoted); !ok {
+				return nil, invalid("unterminated escape")
+			}
+		}
+		items = append(items, item)
+		if rest == "" {
+			return items, nil
+		}
+		if rest[0] != ',' {
+			return nil, invalid(fmt.Sprintf("unexpected %q", rest[0]))
+		}
+		rest = rest[1:]
+	}
+}
+
+// readUnquoted reads an unquoted item, still escaped, up to the first unescaped comma, brace or quote. Returns the
+// item and what follows it
+func readUnquoted(s string) (item, rest string) {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case ',', '{', '}', '"':
+			return s[:i], s[i:]
+		}
+	}
+	return s, ""
+}
+
+// readQuoted reads a double-quoted item, starting after its opening quote, up to its closing quote. Returns the
+// unescaped item and what follows the closing quote, or false if there is no closing quote
+func readQuoted(s string) (item, rest string, ok bool) {
+	out := strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+			if i == len(s) {
+				return "", "", false
+			}
+		case '"':
+			return out.String(), s[i+1:], true
+		}
+		out.WriteByte(s[i])
+	}
+	return "", "", false
+}
+
+func encodeDelimited(items []string, delimiter rune) string {
+	out := strings.Builder{}
+	for i, item := range items {
+		if i > 0 {
+			out.WriteRune(delimiter)
+		}
+		for _, r := range item {
+			if r == delimiter || r == '\\' {
+				out.WriteByte('\\')
+			}
+			out.WriteRune(r)
+		}
+	}
+	return out.String()
+}
+
+func decodeDelimited(value string, delimiter rune) (items []string, err error) {
+	if value == "" {
+		return nil, nil
+	}
+	item := strings.Builder{}
+	escaped := false
+	for _, r := range value {
+		switch {
+		case escaped:
+			item.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == delimiter:
+			items = append(items, item.String())
+			item.Reset()
+		default:
+			item.WriteRune(r)
+		}
+	}
+	if escaped {
+		return nil, fmt.Errorf("%w: unterminated escape in delimited text %q", ErrInvalidSQLValue, value)
+	}
+	return append(items, item.String()), nil
+}
+
+// unescape removes the backslash from each backslash-escaped character of s. Returns false if s ends with an
+// unfinished escape
+func unescape(s string) (string, bool) {
+	if !strings.Contains(s, `\`) {
+		return s, true
+	}
+	out := strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			if i == len(s) {
+				return "", false
+			}
+		}
+		out.WriteByte(s[i])
+	}
+	return out.String(), true
+}
+
+// SQL stores Set in a database column encoded as Options selects, such as delimited text. Use it in place of the
+// set, as a struct field or as the argument to Scan and Exec, when the Postgres array literal of T.Value and T.Scan
+// does not suit. A nil Set is stored as NULL, and scanned into a new *T
+type SQL struct {
+	Set     *T
+	Options SQLOptions
+}
+
+// Value implements driver.Valuer, encoding the set as Options selects, in lexical order
+func (s SQL) Value() (driver.Value, error) {
+	if s.Set == nil {
+		return nil, nil
+	}
+	return EncodeSQL(s.Set, s.Options)
+}
+
+// Scan implements sql.Scanner, replacing the contents of the set with the items in a column encoded as Options
+// selects. NULL scans as an empty set
+func (s *SQL) Scan(src interface{}) error {
+	decoded := NewWithCapacity(0)
+	if err := DecodeSQL(src, decoded, s.Options); err != nil {
+		return err
+	}
+	s.Set = replaced(s.Set, decoded)
+	return nil
+}
+
+// Value implements driver.Valuer, encoding the set as a Postgres array literal, in lexical order. A nil *T is
+// stored as NULL. Use SQL to choose another encoding
+func (c *T) Value() (driver.Value, error) {
+	return SQL{Set: c}.Value()
+}
+
+// Scan implements sql.Scanner, replacing the contents of the set with the items in a Postgres array literal. A
+// zero-value T may be used, and NULL scans as an empty set. Use SQL to choose another encoding
+func (c *T) Scan(src interface{}) error {
+	return (&SQL{Set: c}).Scan(src)
+}
+
+// Value implements driver.Valuer, encoding the snapshot as a Postgres array literal, in lexical order
+func (f *Frozen) Value() (driver.Value, error) {
+	return EncodeSQL(f, SQLOptions{})
+}
@@ -0,0 +1,342 @@
+package string_set
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCollection_Value(t *testing.T) {
+	cases := map[string]struct {
+		input    *T
+		opts     SQLOptions
+		expected driver.Value
+		invalid  bool
+	}{
+		"postgres empty": {
+			input:    New(),
+			expected: `{}`,
+		},
+		"postgres zero value": {
+			input:    &T{},
+			expected: `{}`,
+		},
+		"postgres sorted": {
+			input:    NewOf("c", "a", "b"),
+			expected: `{a,b,c}`,
+		},
+		"postgres quoted": {
+			input:    NewOf("", "a b", `say "hi"`, `back\slash`, "a,b", "{x}", "null", "日本"),
+			expected: `{"","a b","a,b","back\\slash","null","say \"hi\"","{x}",日本}`,
+		},
+		"json": {
+			input:    NewOf("b", "a"),
+			opts:     SQLOptions{Encoding: SQLJSONArray},
+			expected: `["a","b"]`,
+		},
+		"delimited": {
+			input:    NewOf("b", "a", "c,d", `e\f`, ""),
+			opts:     SQLOptions{Encoding: SQLDelimited},
+			expected: `,a,b,c\,d,e\\f`,
+		},
+		"delimited with delimiter": {
+			input:    NewOf("a|b", "c,d"),
+			opts:     SQLOptions{Encoding: SQLDelimited, Delimiter: '|'},
+			expected: `a\|b|c,d`,
+		},
+		"delimited empty": {
+			input:    New(),
+			opts:     SQLOptions{Encoding: SQLDelimited},
+			expected: ``,
+		},
+		"delimited only empty string": {
+			input:   NewOf(""),
+			opts:    SQLOptions{Encoding: SQLDelimited},
+			invalid: true,
+		},
+		"delimited backslash delimiter": {
+			input:   NewOf("a"),
+			opts:    SQLOptions{Encoding: SQLDelimited, Delimiter: '\\'},
+			invalid: true,
+		},
+		"unknown encoding": {
+			input:   NewOf("a"),
+			opts:    SQLOptions{Encoding: 99},
+			invalid: true,
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			actual, err := SQL{Set: c.input, Options: c.opts}.Value()
+			if c.invalid {
+				assert.ErrorIs(t, err, ErrInvalidSQLValue)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, actual)
+		})
+	}
+}
+
+func TestCollection_Scan(t *testing.T) {
+	cases := map[string]struct {
+		input       interface{}
+		opts        SQLOptions
+		expected    []string
+		expectedErr error
+		invalid     bool
+	}{
+		"null": {
+			input: nil,
+		},
+		"postgres empty": {
+			input: `{}`,
+		},
+		"postgres bytes": {
+			input:    []byte(`{b,a}`),
+			expected: []string{"a", "b"},
+		},
+		"postgres quoted": {
+			input:    `{"","a b","a,b","back\\slash","null","say \"hi\"","{x}",日本}`,
+			expected: []string{"", "a b", `say "hi"`, `back\slash`, "a,b", "{x}", "null", "日本"},
+		},
+		"postgres spaces": {
+			input:    ` { a b , "c" ,d } `,
+			expected: []string{"a b", "c", "d"},
+		},
+		"postgres unquoted escape": {
+			input:    `{a\,b}`,
+			expected: []string{"a,b"},
+		},
+		"postgres bounds": {
+			input:    `[0:1]={a,b}`,
+			expected: []string{"a", "b"},
+		},
+		"postgres null item": {
+			input:   `{a,NULL}`,
+			invalid: true,
+		},
+		"postgres nested": {
+			input:   `{{a,b},{c,d}}`,
+			invalid: true,
+		},
+		"postgres unterminated quotes": {
+			input:   `{"a}`,
+			invalid: true,
+		},
+		"postgres missing braces": {
+			input:   `a,b`,
+			invalid: true,
+		},
+		"postgres empty item": {
+			input:   `{a,,b}`,
+			invalid: true,
+		},
+		"postgres duplicates": {
+			input:       `{a,b,a}`,
+			expectedErr: &DuplicateValueError{Value: "a"},
+		},
+		"postgres duplicates allowed": {
+			input:    `{a,b,a}`,
+			opts:     SQLOptions{AllowDuplicates: true},
+			expected: []string{"a", "b"},
+		},
+		"json": {
+			input:    `["b","a"]`,
+			opts:     SQLOptions{Encoding: SQLJSONArray},
+			expected: []string{"a", "b"},
+		},
+		"json duplicates": {
+			input:       `["a","a"]`,
+			opts:        SQLOptions{Encoding: SQLJSONArray},
+			expectedErr: &DuplicateValueError{Value: "a"},
+		},
+		"delimited": {
+			input:    `,a,b,c\,d,e\\f`,
+			opts:     SQLOptions{Encoding: SQLDelimited},
+			expected: []string{"", "a", "b", "c,d", `e\f`},
+		},
+		"delimited empty": {
+			input: ``,
+			opts:  SQLOptions{Encoding: SQLDelimited},
+		},
+		"delimited with delimiter": {
+			input:    `a\|b|c,d`,
+			opts:     SQLOptions{Encoding: SQLDelimited, Delimiter: '|'},
+			expected: []string{"a|b", "c,d"},
+		},
+		"delimited unterminated escape": {
+			input:   `a\`,
+			opts:    SQLOptions{Encoding: SQLDelimited},
+			invalid: true,
+		},
+		"unsupported type": {
+			input:   42,
+			invalid: true,
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			actual := NewOf("unchanged")
+			err := (&SQL{Set: actual, Options: c.opts}).Scan(c.input)
+			if c.invalid {
+				assert.ErrorIs(t, err, ErrInvalidSQLValue)
+			} else if c.expectedErr != nil {
+				assert.Equal(t, c.expectedErr, err)
+			}
+			if c.invalid || c.expectedErr != nil {
+				assert.True(t, NewOf("unchanged").IsEqualTo(actual))
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, NewOf(c.expected...).IsEqualTo(actual))
+		})
+	}
+}
+
+func TestCollection_SQLRoundTrip(t *testing.T) {
+	for _, encoding := range []SQLEncoding{SQLPostgresArray, SQLJSONArray, SQLDelimited} {
+		t.Run(encoding.String(), func(t *testing.T) {
+			input := NewOf("", "a", "a b", `"quoted"`, `\`, ",", "|", "{}", "NULL", "日本")
+			value, err := SQL{Set: input, Options: SQLOptions{Encoding: encoding}}.Value()
+			assert.NoError(t, err)
+
+			actual := &SQL{Options: SQLOptions{Encoding: encoding}}
+			assert.NoError(t, actual.Scan(value))
+			assert.True(t, input.IsEqualTo(actual.Set))
+		})
+	}
+}
+
+// fakeDriver is a database/sql driver holding a single table in memory. Statements starting with INSERT add a row
+// of their arguments, any other statement returns every row
+type fakeDriver struct {
+	mu   sync.Mutex
+	rows [][]driver.Value
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) {
+	return &fakeConn{driver: d}, nil
+}
+
+type fakeConn struct {
+	driver *fakeDriver
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{conn: c, insert: strings.HasPrefix(query, "INSERT")}, nil
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("fakeDriver: transactions are not supported")
+}
+
+type fakeStmt struct {
+	conn   *fakeConn
+	insert bool
+}
+
+func (s *fakeStmt) Close() error {
+	return nil
+}
+
+func (s *fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.conn.driver.mu.Lock()
+	defer s.conn.driver.mu.Unlock()
+	s.conn.driver.rows = append(s.conn.driver.rows, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	s.conn.driver.mu.Lock()
+	defer s.conn.driver.mu.Unlock()
+	return &fakeRows{rows: append([][]driver.Value{}, s.conn.driver.rows...)}, nil
+}
+
+type fakeRows struct {
+	rows [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	if len(r.rows) == 0 {
+		return nil
+	}
+	return make([]string, len(r.rows[0]))
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+var registerFakeDriver sync.Once
+
+func openFakeDB(t *testing.T, name string) *sql.DB {
+	registerFakeDriver.Do(func() {
+		sql.Register("string_set_fake", &fakeDriver{})
+	})
+	db, err := sql.Open("string_set_fake", name)
+	assert.NoError(t, err)
+	return db
+}
+
+type taggedRecord struct {
+	Name string
+	// Tags is stored as a Postgres array
+	Tags T
+	// Labels is stored as delimited text, and may be NULL
+	Labels SQL
+}
+
+func TestCollection_SQLStructFields(t *testing.T) {
+	db := openFakeDB(t, t.Name())
+	defer db.Close()
+
+	delimited := SQLOptions{Encoding: SQLDelimited}
+	_, err := db.Exec("INSERT", "first", NewOf("b", "a b"), SQL{Set: NewOf("x,y", "z"), Options: delimited})
+	assert.NoError(t, err)
+	_, err = db.Exec("INSERT", "second", New(), SQL{Options: delimited})
+	assert.NoError(t, err)
+
+	rows, err := db.Query("SELECT")
+	assert.NoError(t, err)
+	defer rows.Close()
+
+	var records []taggedRecord
+	for rows.Next() {
+		record := taggedRecord{Labels: SQL{Options: delimited}}
+		assert.NoError(t, rows.Scan(&record.Name, &record.Tags, &record.Labels))
+		records = append(records, record)
+	}
+	assert.NoError(t, rows.Err())
+
+	assert.Len(t, records, 2)
+	assert.Equal(t, "first", records[0].Name)
+	assert.True(t, NewOf("a b", "b").IsEqualTo(&records[0].Tags))
+	assert.True(t, NewOf("x,y", "z").IsEqualTo(records[0].Labels.Set))
+	assert.True(t, records[1].Tags.IsEmpty())
+	assert.True(t, records[1].Labels.Set.IsEmpty())
+}
@@ -1,12 +1,17 @@
 package string_set
 
+import (
+	"github.com/wojnosystems/go-string-set/generic_set"
+	"iter"
+)
+
 const (
 	defaultCapacity = 10
 )
 
 // Empty is a convenience declaration: it's an empty set you can use to compare
-// to other sets if you want to use IsEqualTo instead of testing with Len
-var Empty = NewWithCapacity(0)
+// to other sets if you want to use IsEqualTo instead of testing with Len. It is frozen, so it cannot be altered
+var Empty = NewWithCapacity(0).Freeze()
 
 // New creates a new String set, with a small default capacity
 func New() *T {
@@ -20,152 +25,154 @@ func NewOf(items ...string) *T {
 	return ret
 }
 
+// Collect creates a new string set containing every string produced by seq
+func Collect(seq iter.Seq[string]) *T {
+	ret := New()
+	for v := range seq {
+		ret.Add(v)
+	}
+	return ret
+}
+
 // NewWithCapacity creates a new, empty, string set with the provided capacity
 func NewWithCapacity(capacity int) *T {
 	return &T{
-		items: make(map[string]bool, capacity),
+		T: *generic_set.NewWithCapacity[string](capacity),
 	}
 }
 
 // T holds the underlying string_set type, do not instantiate this yourself,
-// Please use New, NewOf, or NewWithCapacity
+// Please use New, NewOf, or NewWithCapacity. The zero value is an empty set ready to use
+//
+// T is a generic_set.T specialized for strings. The set operations are overridden so that they return *T
 type T struct {
-	items map[string]bool
+	generic_set.T[string]
 }
 
-func (c *T) Add(v string) {
-	c.items[v] = true
+// IsEqualTo returns true if both sets contain the same strings. If o does not compare strings byte-for-byte, such
+// as a case-insensitive set, the strings are compared the way CompareKey describes
+func (c *T) IsEqualTo(o Immutable) bool {
+	return IsEqual(c, o)
 }
 
-func (c *T) AddMany(v ...string) {
-	for _, s := range v {
-		c.Add(s)
-	}
+// Union returns a new set containing all of the items from the callee and the parameter. Items of o that are the
+// same as an item of the callee, as compared by CompareKey, are not added
+func (c *T) Union(o Immutable) (out Interface) {
+	out = c.Copy()
+	UnionInto(out, c, o)
+	return
 }
 
-func (c *T) Remove(v string) {
-	delete(c.items, v)
+// Subtract returns a new set containing only items from the callee, but without the items in the parameter, as
+// compared by CompareKey
+func (c *T) Subtract(o Immutable) (out Interface) {
+	out = NewWithCapacity(c.Len())
+	SubtractInto(out, c, o)
+	return
 }
 
-func (c *T) RemoveMany(v ...string) {
-	for _, s := range v {
-		c.Remove(s)
-	}
+// Intersection returns a new set containing only the items of the callee that are also in the parameter, as
+// compared by CompareKey
+func (c *T) Intersection(o Immutable) (out Interface) {
+	out = NewWithCapacity(c.Len())
+	IntersectionInto(out, c, o)
+	return
 }
 
-func (c *T) Includes(v string) bool {
-	_, ok := c.items[v]
-	return ok
+// SymmetricDifference returns a new set containing the items of either the callee or the parameter that are
+// not in both, as compared by CompareKey
+func (c *T) SymmetricDifference(o Immutable) (out Interface) {
+	out = NewWithCapacity(c.Len())
+	SymmetricDifferenceInto(out, c, o)
+	return
 }
 
-func (c *T) IsEmpty() bool {
-	return c.Len() == 0
+// IsSubsetOf returns true if o contains every item of the set, as compared by CompareKey
+func (c *T) IsSubsetOf(o Immutable) bool {
+	return IsSubset(c, o)
 }
 
-func (c *T) Len() int {
-	return len(c.items)
+// IsProperSubsetOf returns true if o contains every item of the set and has items the set does not, as compared
+// by CompareKey
+func (c *T) IsProperSubsetOf(o Immutable) bool {
+	return IsProperSubset(c, o)
 }
 
-func (c *T) IsEqualTo(o Immutable) (equal bool) {
-	// short-circuit test for speed
-	if c.Len() != o.Len() {
-		return false
-	}
-	equal = true
-	c.EachCancelable(func(v string) NextAction {
-		if !o.Includes(v) {
-			equal = false
-			return Break
-		}
-		return Continue
-	})
-	return
+// IsSupersetOf returns true if the set contains every item of o, as compared by CompareKey
+func (c *T) IsSupersetOf(o Immutable) bool {
+	return IsSubset(o, c)
 }
 
-func (c *T) Union(o Immutable) (out Interface) {
-	out = c.Copy()
-	o.Each(func(v string) {
-		out.Add(v)
-	})
-	return
+// IsDisjointFrom returns true if the set and o have no items in common, as compared by CompareKey
+func (c *T) IsDisjointFrom(o Immutable) bool {
+	return IsDisjoint(c, o)
 }
 
-func (c *T) Subtract(o Immutable) (out Interface) {
-	out = NewWithCapacity(c.Len())
-	c.Each(func(v string) {
-		if !o.Includes(v) {
-			out.Add(v)
-		}
-	})
-	return
+// IntersectionLen returns the number of items Intersection would return, without creating the intersection
+func (c *T) IntersectionLen(o Immutable) int {
+	return IntersectionLen(c, o)
 }
 
-func (c *T) Intersection(o Immutable) (out Interface) {
-	out = NewWithCapacity(c.Len())
-	o.Each(func(v string) {
-		if c.Includes(v) {
-			out.Add(v)
-		}
-	})
-	return
+// UnionWith adds the items of o to the set, without creating a new set. Returns the number of items added. Items of
+// o that are the same as an item of the set, as compared by CompareKey, are not added
+func (c *T) UnionWith(o Immutable) (changed int) {
+	if normalizerOf(o) == nil {
+		return c.T.UnionWith(genericOf(o))
+	}
+	return UnionWith(c, o)
 }
 
-func (c *T) ToSlice() (out []string) {
-	out = make([]string, c.Len())
-	i := 0
-	for s := range c.items {
-		out[i] = s
-		i++
+// SubtractWith removes the items that o contains, as compared by CompareKey, without creating a new set. Returns
+// the number of items removed
+func (c *T) SubtractWith(o Immutable) (changed int) {
+	if normalizerOf(o) == nil {
+		return c.T.SubtractWith(genericOf(o))
 	}
-	return
+	return SubtractWith(c, o)
 }
 
-func (c *T) Each(item func(v string)) {
-	for value := range c.items {
-		item(value)
+// IntersectWith removes the items that o does not contain, as compared by CompareKey, without creating a new set.
+// Returns the number of items removed
+func (c *T) IntersectWith(o Immutable) (changed int) {
+	if normalizerOf(o) == nil {
+		return c.T.IntersectWith(genericOf(o))
 	}
+	return IntersectWith(c, o)
 }
 
-func (c *T) EachCancelable(item func(v string) (next NextAction)) {
-	for value := range c.items {
-		action := item(value)
-		if action == Break {
-			break
-		}
+// SymmetricDifferenceWith removes the items that o contains, and adds the items of o that the set did not contain,
+// as compared by CompareKey, without creating a new set. Returns the number of items added or removed
+func (c *T) SymmetricDifferenceWith(o Immutable) (changed int) {
+	if normalizerOf(o) == nil {
+		return c.T.SymmetricDifferenceWith(genericOf(o))
 	}
+	return SymmetricDifferenceWith(c, o)
 }
 
-// Any returns true if predicate returns true for any item. Short-circuits and stops iteration when didMatch
-// returns true. Returns false if no item caused predicate to return true
-func (c *T) Any(item func(v string) (didMatch bool)) (anyFound bool) {
-	c.EachCancelable(func(v string) (a NextAction) {
-		if item(v) {
-			anyFound = true
-			return Break
-		}
-		return
-	})
-	return
+// Apply removes the items d removed and adds the items d added, as SubtractWith and UnionWith do. Returns the number
+// of items removed or added
+func (c *T) Apply(d DiffResult) (changed int) {
+	return c.SubtractWith(d.Removed) + c.UnionWith(d.Added)
 }
 
-// None return true if predicate returned false for every item in the set. If predicate returns true, short-circuit
-// and return false from this method, indicating that at least 1 item matched
-func (c *T) None(item func(v string) (didMatch bool)) (noneFound bool) {
-	noneFound = true
-	c.EachCancelable(func(v string) (a NextAction) {
-		if item(v) {
-			noneFound = false
-			return Break
-		}
-		return
-	})
-	return
+// genericOf returns the generic_set.T o wraps if o is a *T, so that generic_set.T can recognize itself and use its
+// items directly
+func genericOf(o Immutable) Immutable {
+	if other, ok := o.(*T); ok {
+		return &other.T
+	}
+	return o
+}
+
+// Sorted returns an iterator over the items of the set in lexical order
+func (c *T) Sorted() iter.Seq[string] {
+	return generic_set.Sorted[string](c)
 }
 
 func (c *T) Copy() Interface {
 	outItems := NewWithCapacity(c.Len())
-	for s := range c.items {
-		outItems.Add(s)
-	}
+	c.Each(func(v string) {
+		outItems.Add(v)
+	})
 	return outItems
 }
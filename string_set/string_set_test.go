@@ -2,7 +2,11 @@ package string_set
 
 import (
 	"github.com/stretchr/testify/assert"
+	"iter"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -112,6 +116,26 @@ func TestCollection_Len(t *testing.T) {
 	assert.Equal(t, 1, set.Len())
 }
 
+func TestCollection_ZeroValue(t *testing.T) {
+	var set T
+	assert.Equal(t, 0, set.Len())
+	assert.True(t, set.IsEmpty())
+	assert.False(t, set.Includes("something"))
+	assert.Empty(t, set.ToSlice())
+	set.Each(func(v string) {
+		assert.Fail(t, "zero value has no items", v)
+	})
+	assert.True(t, set.IsEqualTo(Empty))
+
+	set.Add("something")
+	assert.True(t, set.Includes("something"))
+	assert.Equal(t, 1, set.Len())
+
+	var other T
+	assert.Equal(t, 1, other.UnionWith(&set))
+	assert.True(t, other.IsEqualTo(&set))
+}
+
 func TestCollection_IsEqualTo(t *testing.T) {
 	cases := map[string]struct {
 		a        Immutable
@@ -188,7 +212,7 @@ func TestCollection_Any(t *testing.T) {
 		expected bool
 	}{
 		"empty": {
-			input: Empty,
+			input: New(),
 			test: func(v string) bool {
 				return true
 			},
@@ -222,7 +246,7 @@ func TestCollection_None(t *testing.T) {
 		expected bool
 	}{
 		"empty": {
-			input: Empty,
+			input: New(),
 			test: func(v string) bool {
 				return true
 			},
@@ -431,3 +455,367 @@ func TestCollection_Intersection(t *testing.T) {
 		})
 	}
 }
+
+func TestCollection_SymmetricDifference(t *testing.T) {
+	cases := map[string]struct {
+		a        Immutable
+		b        Immutable
+		expected Immutable
+	}{
+		"empty": {
+			a:        Empty,
+			b:        Empty,
+			expected: Empty,
+		},
+		"b empty": {
+			a:        NewOf("a", "b"),
+			b:        Empty,
+			expected: NewOf("a", "b"),
+		},
+		"overlapping items": {
+			a:        NewOf("a", "b"),
+			b:        NewOf("a", "b"),
+			expected: Empty,
+		},
+		"partial overlap items": {
+			a:        NewOf("a", "b"),
+			b:        NewOf("b", "c"),
+			expected: NewOf("a", "c"),
+		},
+		"normalized": {
+			a:        NewOf("a", "B"),
+			b:        NewNormalizedOf(strings.ToLower, "b", "c"),
+			expected: NewOf("a", "c"),
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			actual := c.a.SymmetricDifference(c.b)
+			assert.True(t, c.expected.IsEqualTo(actual))
+			assert.True(t, c.expected.IsEqualTo(c.b.SymmetricDifference(c.a)))
+		})
+	}
+}
+
+func TestCollection_Predicates(t *testing.T) {
+	cases := map[string]struct {
+		a               Immutable
+		b               Immutable
+		subset          bool
+		properSubset    bool
+		superset        bool
+		disjoint        bool
+		intersectionLen int
+	}{
+		"empty": {
+			a:        Empty,
+			b:        Empty,
+			subset:   true,
+			superset: true,
+			disjoint: true,
+		},
+		"a empty": {
+			a:            Empty,
+			b:            NewOf("a"),
+			subset:       true,
+			properSubset: true,
+			disjoint:     true,
+		},
+		"equal": {
+			a:               NewOf("a", "b"),
+			b:               NewOf("b", "a"),
+			subset:          true,
+			superset:        true,
+			intersectionLen: 2,
+		},
+		"proper subset": {
+			a:               NewOf("a"),
+			b:               NewOf("a", "b"),
+			subset:          true,
+			properSubset:    true,
+			intersectionLen: 1,
+		},
+		"superset": {
+			a:               NewOf("a", "b", "c").Freeze(),
+			b:               NewOf("c"),
+			superset:        true,
+			intersectionLen: 1,
+		},
+		"partial overlap items": {
+			a:               NewOf("a", "b"),
+			b:               NewOf("b", "c"),
+			intersectionLen: 1,
+		},
+		"disjoint": {
+			a:        NewOf("a", "b"),
+			b:        NewOf("c"),
+			disjoint: true,
+		},
+		"normalized": {
+			a:               NewOf("a", "A"),
+			b:               NewNormalizedOf(strings.ToLower, "a"),
+			subset:          true,
+			superset:        true,
+			intersectionLen: 2,
+		},
+		"normalized proper subset": {
+			a:               NewNormalizedOf(strings.ToLower, "A"),
+			b:               NewOf("a", "b"),
+			subset:          true,
+			properSubset:    true,
+			intersectionLen: 1,
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			assert.Equal(t, c.subset, c.a.IsSubsetOf(c.b))
+			assert.Equal(t, c.properSubset, c.a.IsProperSubsetOf(c.b))
+			assert.Equal(t, c.superset, c.a.IsSupersetOf(c.b))
+			assert.Equal(t, c.superset, c.b.IsSubsetOf(c.a))
+			assert.Equal(t, c.subset, c.b.IsSupersetOf(c.a))
+			assert.Equal(t, c.disjoint, c.a.IsDisjointFrom(c.b))
+			assert.Equal(t, c.disjoint, c.b.IsDisjointFrom(c.a))
+			assert.Equal(t, c.intersectionLen, c.a.IntersectionLen(c.b))
+			assert.Equal(t, c.a.Intersection(c.b).Len(), c.a.IntersectionLen(c.b))
+		})
+	}
+}
+
+func TestIntersectionLen_DoesNotAllocatePerItem(t *testing.T) {
+	large := New()
+	for i := 0; i < 1000; i++ {
+		large.Add(strconv.Itoa(i))
+	}
+
+	// only the iteration callbacks are allocated, however many items are counted
+	assert.Equal(t, 1000, large.IntersectionLen(large.Copy()))
+	assert.Less(t, testing.AllocsPerRun(10, func() {
+		large.IntersectionLen(large.Copy())
+	})-testing.AllocsPerRun(10, func() {
+		large.Copy()
+	}), 10.0)
+}
+
+func TestCollection_InPlaceSetter(t *testing.T) {
+	cases := map[string]struct {
+		a                          []string
+		b                          Immutable
+		union                      []string
+		unionChanged               int
+		subtract                   []string
+		subtractChanged            int
+		intersection               []string
+		intersectionChanged        int
+		symmetricDifference        []string
+		symmetricDifferenceChanged int
+	}{
+		"empty": {
+			b: Empty,
+		},
+		"b empty": {
+			a:                   []string{"a", "b"},
+			b:                   Empty,
+			union:               []string{"a", "b"},
+			subtract:            []string{"a", "b"},
+			intersectionChanged: 2,
+			symmetricDifference: []string{"a", "b"},
+		},
+		"partial overlap items": {
+			a:                          []string{"a", "b"},
+			b:                          NewOf("b", "c").Freeze(),
+			union:                      []string{"a", "b", "c"},
+			unionChanged:               1,
+			subtract:                   []string{"a"},
+			subtractChanged:            1,
+			intersection:               []string{"b"},
+			intersectionChanged:        1,
+			symmetricDifference:        []string{"a", "c"},
+			symmetricDifferenceChanged: 2,
+		},
+		"normalized": {
+			a:                          []string{"a", "B"},
+			b:                          NewNormalizedOf(strings.ToLower, "b", "c"),
+			union:                      []string{"a", "B", "c"},
+			unionChanged:               1,
+			subtract:                   []string{"a"},
+			subtractChanged:            1,
+			intersection:               []string{"B"},
+			intersectionChanged:        1,
+			symmetricDifference:        []string{"a", "c"},
+			symmetricDifferenceChanged: 2,
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			union := NewOf(c.a...)
+			assert.Equal(t, c.unionChanged, union.UnionWith(c.b))
+			assert.ElementsMatch(t, c.union, union.ToSlice())
+			subtract := NewOf(c.a...)
+			assert.Equal(t, c.subtractChanged, subtract.SubtractWith(c.b))
+			assert.ElementsMatch(t, c.subtract, subtract.ToSlice())
+			intersection := NewOf(c.a...)
+			assert.Equal(t, c.intersectionChanged, intersection.IntersectWith(c.b))
+			assert.ElementsMatch(t, c.intersection, intersection.ToSlice())
+			symmetricDifference := NewOf(c.a...)
+			assert.Equal(t, c.symmetricDifferenceChanged, symmetricDifference.SymmetricDifferenceWith(c.b))
+			assert.ElementsMatch(t, c.symmetricDifference, symmetricDifference.ToSlice())
+
+			// the same operations on a Normalized set take the normalized set's way of comparing strings
+			normalized := NewNormalizedOf(strings.TrimSpace, c.a...)
+			normalized.UnionWith(c.b)
+			assert.True(t, normalized.IsEqualTo(NewOf(c.a...).Union(c.b)))
+		})
+	}
+}
+
+func TestCollection_InPlaceSetterWithItself(t *testing.T) {
+	set := NewOf("a", "b")
+	assert.Equal(t, 0, set.UnionWith(set))
+	assert.Equal(t, 0, set.IntersectWith(set))
+	assert.ElementsMatch(t, []string{"a", "b"}, set.ToSlice())
+	assert.Equal(t, 2, set.SymmetricDifferenceWith(set))
+	assert.True(t, set.IsEmpty())
+
+	normalized := NewNormalizedOf(strings.ToLower, "a", "b")
+	assert.Equal(t, 2, normalized.SubtractWith(normalized))
+	assert.True(t, normalized.IsEmpty())
+}
+
+func TestCollection_Freeze(t *testing.T) {
+	set := NewOf("a", "b")
+	frozen := set.Freeze()
+	set.Add("c")
+	set.Remove("a")
+
+	assert.True(t, NewOf("a", "b").IsEqualTo(frozen))
+	_, isMutable := interface{}(frozen).(Mutable)
+	assert.False(t, isMutable)
+	_, isMutable = interface{}(Empty).(Mutable)
+	assert.False(t, isMutable)
+}
+
+func TestCollection_All(t *testing.T) {
+	input := NewOf("a", "b", "c", "d")
+
+	actual := New()
+	for v := range input.All() {
+		actual.Add(v)
+	}
+	assert.True(t, input.IsEqualTo(actual))
+
+	count := 0
+	for range input.All() {
+		count++
+		break
+	}
+	assert.Equal(t, 1, count)
+}
+
+func TestCollection_Sorted(t *testing.T) {
+	cases := map[string]struct {
+		input    Immutable
+		expected []string
+	}{
+		"empty": {
+			input:    Empty,
+			expected: nil,
+		},
+		"not empty": {
+			input:    NewOf("d", "b", "a", "c"),
+			expected: []string{"a", "b", "c", "d"},
+		},
+		"frozen": {
+			input:    NewOf("b", "a").Freeze(),
+			expected: []string{"a", "b"},
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			var actual []string
+			for v := range c.input.(interface{ Sorted() iter.Seq[string] }).Sorted() {
+				actual = append(actual, v)
+			}
+			assert.Equal(t, c.expected, actual)
+		})
+	}
+}
+
+func TestCollect(t *testing.T) {
+	actual := Collect(slices.Values([]string{"a", "b", "a"}))
+	assert.True(t, NewOf("a", "b").IsEqualTo(actual))
+	assert.Equal(t, []string{"a", "b"}, slices.Collect(actual.Sorted()))
+}
+
+func TestCollection_ToBloom(t *testing.T) {
+	set := NewOf("a", "b", "c")
+	var includer Includer = set.ToBloom(0.01)
+	set.Each(func(v string) {
+		assert.True(t, includer.Includes(v))
+	})
+	includer = set.Freeze().ToBloom(0.01)
+	assert.True(t, includer.Includes("a"))
+}
+
+// foldedSets are the sets the fold benchmarks combine, 200 sets of 100 items each, overlapping by half
+func foldedSets() (sets []Immutable) {
+	for i := 0; i < 200; i++ {
+		set := NewWithCapacity(100)
+		for j := 0; j < 100; j++ {
+			set.Add(strconv.Itoa(i*50 + j))
+		}
+		sets = append(sets, set)
+	}
+	return
+}
+
+func BenchmarkFold_Union(b *testing.B) {
+	sets := foldedSets()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var out Interface = New()
+		for _, set := range sets {
+			out = out.Union(set)
+		}
+	}
+}
+
+func BenchmarkFold_UnionWith(b *testing.B) {
+	sets := foldedSets()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out := New()
+		for _, set := range sets {
+			out.UnionWith(set)
+		}
+	}
+}
+
+func BenchmarkFold_Intersection(b *testing.B) {
+	sets := foldedSets()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out := sets[0].Copy()
+		for _, set := range sets[1:] {
+			out = out.Intersection(set)
+		}
+	}
+}
+
+func BenchmarkFold_IntersectWith(b *testing.B) {
+	sets := foldedSets()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out := sets[0].Copy()
+		for _, set := range sets[1:] {
+			out.IntersectWith(set)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package string_set
+
+import (
+	"encoding/xml"
+)
+
+// DefaultXMLElementName is the name of the element each item is encoded in when XMLOptions.ElementName is empty
+const DefaultXMLElementName = "item"
+
+// XMLOptions controls how a set is encoded to and decoded from XML
+type XMLOptions struct {
+	// ElementName is the name of the element each item is encoded in, and the only element decoded as an item.
+	// Defaults to DefaultXMLElementName
+	ElementName string
+	// AllowDuplicates tolerates a value appearing more than once. When false, decoding a repeated value fails
+	// with a *DuplicateValueError
+	AllowDuplicates bool
+}
+
+func (o XMLOptions) elementName() xml.Name {
+	if o.ElementName == "" {
+		return xml.Name{Local: DefaultXMLElementName}
+	}
+	return xml.Name{Local: o.ElementName}
+}
+
+// EncodeXML writes start, then each item of s in lexical order as a repeated element, then the end of start.
+// Intended for implementing xml.Marshaler
+func EncodeXML(e *xml.Encoder, start xml.StartElement, s Immutable, opts XMLOptions) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	item := xml.StartElement{Name: opts.elementName()}
+	for _, v := range SortedSlice(s) {
+		if err := e.EncodeElement(v, item); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// DecodeXML adds the text of each item element inside start to into. Other elements are skipped. Duplicates are
+// detected using into's own comparison rules. Intended for implementing xml.Unmarshaler
+func DecodeXML(d *xml.Decoder, start xml.StartElement, into Interface, opts XMLOptions) error {
+	name := opts.elementName()
+	var items []string
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local != name.Local {
+				if err = d.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			var item string
+			if err = d.DecodeElement(&item, &t); err != nil {
+				return err
+			}
+			items = append(items, item)
+		case xml.EndElement:
+			return addDecoded(into, items, opts.AllowDuplicates)
+		}
+	}
+}
+
+// XML encodes and decodes Set as XML with Options, such as a different name for the item elements. Use it in place
+// of the set, as a struct field or as the argument to xml.Marshal and xml.Unmarshal, when the defaults of
+// T.MarshalXML and T.UnmarshalXML do not suit. A nil Set is encoded as an empty element, and decoded into a new *T
+type XML struct {
+	Set     *T
+	Options XMLOptions
+}
+
+// MarshalXML encodes the set as one element per item, in lexical order, inside start
+func (x XML) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return EncodeXML(e, start, orEmpty(x.Set), x.Options)
+}
+
+// UnmarshalXML replaces the contents of the set with the text of each item element inside start. Repeated values
+// are rejected unless Options allows them
+func (x *XML) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	decoded := NewWithCapacity(0)
+	if err := DecodeXML(d, start, decoded, x.Options); err != nil {
+		return err
+	}
+	x.Set = replaced(x.Set, decoded)
+	return nil
+}
+
+// MarshalXML encodes the set as one element per item, named DefaultXMLElementName, in lexical order, inside start.
+// Use XML to choose another element name
+func (c *T) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return EncodeXML(e, start, c, XMLOptions{})
+}
+
+// UnmarshalXML replaces the contents of the set with the text of each element named DefaultXMLElementName inside
+// start. A zero-value T may be used. Repeated values are rejected; use XML to allow them
+func (c *T) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return (&XML{Set: c}).UnmarshalXML(d, start)
+}
+
+// MarshalXML encodes the snapshot as one element per item, named DefaultXMLElementName, in lexical order
+func (f *Frozen) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return EncodeXML(e, start, f, XMLOptions{})
+}
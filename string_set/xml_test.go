@@ -0,0 +1,130 @@
+package string_set
+
+import (
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type xmlDocument struct {
+	XMLName xml.Name `xml:"doc"`
+	Tags    *T       `xml:"tags"`
+}
+
+type xmlOptionsDocument struct {
+	XMLName xml.Name `xml:"doc"`
+	Tags    XML      `xml:"tags"`
+}
+
+func TestCollection_MarshalXML(t *testing.T) {
+	cases := map[string]struct {
+		input    xml.Marshaler
+		expected string
+	}{
+		"empty": {
+			input:    New(),
+			expected: `<doc><tags></tags></doc>`,
+		},
+		"zero value": {
+			input:    &T{},
+			expected: `<doc><tags></tags></doc>`,
+		},
+		"sorted": {
+			input:    NewOf("c", "a", "b"),
+			expected: `<doc><tags><item>a</item><item>b</item><item>c</item></tags></doc>`,
+		},
+		"escaped": {
+			input:    NewOf("<b>", "a&b"),
+			expected: `<doc><tags><item>&lt;b&gt;</item><item>a&amp;b</item></tags></doc>`,
+		},
+		"element name": {
+			input:    XML{Set: NewOf("b", "a"), Options: XMLOptions{ElementName: "tag"}},
+			expected: `<doc><tags><tag>a</tag><tag>b</tag></tags></doc>`,
+		},
+		"nil set with options": {
+			input:    XML{Options: XMLOptions{ElementName: "tag"}},
+			expected: `<doc><tags></tags></doc>`,
+		},
+		"frozen": {
+			input:    NewOf("b", "a").Freeze(),
+			expected: `<doc><tags><item>a</item><item>b</item></tags></doc>`,
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			actual, err := xml.Marshal(struct {
+				XMLName xml.Name      `xml:"doc"`
+				Tags    xml.Marshaler `xml:"tags"`
+			}{Tags: c.input})
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, string(actual))
+		})
+	}
+}
+
+func TestCollection_UnmarshalXML(t *testing.T) {
+	cases := map[string]struct {
+		input       string
+		opts        XMLOptions
+		expected    Immutable
+		expectedErr error
+	}{
+		"empty": {
+			input:    `<doc><tags/></doc>`,
+			expected: Empty,
+		},
+		"items": {
+			input:    `<doc><tags><item>b</item><item>a</item><item></item></tags></doc>`,
+			expected: NewOf("a", "b", ""),
+		},
+		"other elements skipped": {
+			input:    `<doc><tags><item>a</item><other><item>x</item></other>text</tags></doc>`,
+			expected: NewOf("a"),
+		},
+		"element name": {
+			input:    `<doc><tags><tag>a</tag><item>x</item></tags></doc>`,
+			opts:     XMLOptions{ElementName: "tag"},
+			expected: NewOf("a"),
+		},
+		"duplicates rejected": {
+			input:       `<doc><tags><item>a</item><item>a</item></tags></doc>`,
+			expectedErr: &DuplicateValueError{Value: "a"},
+		},
+		"duplicates allowed": {
+			input:    `<doc><tags><item>a</item><item>a</item></tags></doc>`,
+			opts:     XMLOptions{AllowDuplicates: true},
+			expected: NewOf("a"),
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			actual := xmlOptionsDocument{Tags: XML{Options: c.opts}}
+			err := xml.Unmarshal([]byte(c.input), &actual)
+			if c.expectedErr != nil {
+				assert.Equal(t, c.expectedErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, c.expected.IsEqualTo(actual.Tags.Set))
+		})
+	}
+}
+
+func TestCollection_XMLRoundTrip(t *testing.T) {
+	data, err := xml.Marshal(xmlDocument{Tags: NewOf("b", "a", "c")})
+	assert.NoError(t, err)
+
+	var actual xmlDocument
+	assert.NoError(t, xml.Unmarshal(data, &actual))
+	assert.True(t, NewOf("a", "b", "c").IsEqualTo(actual.Tags))
+}
+
+func TestXML_DecodesIntoSet(t *testing.T) {
+	tags := NewOf("old")
+	actual := xmlOptionsDocument{Tags: XML{Set: tags, Options: XMLOptions{ElementName: "tag"}}}
+	assert.NoError(t, xml.Unmarshal([]byte(`<doc><tags><tag>a</tag></tags></doc>`), &actual))
+	assert.Same(t, tags, actual.Tags.Set)
+	assert.True(t, NewOf("a").IsEqualTo(tags))
+}
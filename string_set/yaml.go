@@ -0,0 +1,59 @@
+package string_set
+
+// YAMLOptions controls how a set is decoded from YAML
+type YAMLOptions struct {
+	// AllowDuplicates tolerates a value appearing more than once in the YAML sequence. When false, decoding a
+	// repeated value fails with a *DuplicateValueError
+	AllowDuplicates bool
+}
+
+// DecodeYAML decodes a YAML sequence of strings with unmarshal, as passed to UnmarshalYAML, and adds each string to
+// into. Duplicates are detected using into's own comparison rules. A null adds nothing
+func DecodeYAML(unmarshal func(interface{}) error, into Interface, opts YAMLOptions) error {
+	var items []string
+	if err := unmarshal(&items); err != nil {
+		return err
+	}
+	return addDecoded(into, items, opts.AllowDuplicates)
+}
+
+// YAML encodes and decodes Set as a YAML sequence, decoding with Options. Use it in place of the set, as a struct
+// field or as the argument to Unmarshal, when the defaults of T.UnmarshalYAML do not suit. A nil Set is encoded as
+// an empty sequence, and decoded into a new *T. It works with both gopkg.in/yaml.v2 and gopkg.in/yaml.v3
+type YAML struct {
+	Set     *T
+	Options YAMLOptions
+}
+
+// MarshalYAML encodes the set as a YAML sequence of strings in lexical order
+func (y YAML) MarshalYAML() (interface{}, error) {
+	return SortedSlice(orEmpty(y.Set)), nil
+}
+
+// UnmarshalYAML replaces the contents of the set with the strings in a YAML sequence. Repeated values are rejected
+// unless Options allows them
+func (y *YAML) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	decoded := NewWithCapacity(0)
+	if err := DecodeYAML(unmarshal, decoded, y.Options); err != nil {
+		return err
+	}
+	y.Set = replaced(y.Set, decoded)
+	return nil
+}
+
+// MarshalYAML encodes the set as a YAML sequence of strings in lexical order. It works with both gopkg.in/yaml.v2
+// and gopkg.in/yaml.v3
+func (c *T) MarshalYAML() (interface{}, error) {
+	return SortedSlice(c), nil
+}
+
+// UnmarshalYAML replaces the contents of the set with the strings in a YAML sequence. A zero-value T may be used.
+// Repeated values are rejected; use YAML to allow them. It works with both gopkg.in/yaml.v2 and gopkg.in/yaml.v3
+func (c *T) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return (&YAML{Set: c}).UnmarshalYAML(unmarshal)
+}
+
+// MarshalYAML encodes the snapshot as a YAML sequence of strings in lexical order
+func (f *Frozen) MarshalYAML() (interface{}, error) {
+	return SortedSlice(f), nil
+}
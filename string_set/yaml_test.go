@@ -0,0 +1,103 @@
+package string_set
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+type yamlDocument struct {
+	Tags *T `yaml:"tags"`
+}
+
+func TestCollection_MarshalYAML(t *testing.T) {
+	cases := map[string]struct {
+		input    yaml.Marshaler
+		expected string
+	}{
+		"empty": {
+			input:    New(),
+			expected: "tags: []\n",
+		},
+		"zero value": {
+			input:    &T{},
+			expected: "tags: []\n",
+		},
+		"sorted": {
+			input:    NewOf("c", "a", "b"),
+			expected: "tags:\n    - a\n    - b\n    - c\n",
+		},
+		"quoted": {
+			input:    NewOf("yes", "1", ""),
+			expected: "tags:\n    - \"\"\n    - \"1\"\n    - \"yes\"\n",
+		},
+		"frozen": {
+			input:    NewOf("b", "a").Freeze(),
+			expected: "tags:\n    - a\n    - b\n",
+		},
+		"nil set": {
+			input:    YAML{},
+			expected: "tags: []\n",
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			actual, err := yaml.Marshal(map[string]yaml.Marshaler{"tags": c.input})
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, string(actual))
+		})
+	}
+}
+
+func TestCollection_UnmarshalYAML(t *testing.T) {
+	cases := map[string]struct {
+		input       string
+		opts        YAMLOptions
+		expected    Immutable
+		expectedErr error
+	}{
+		"empty": {
+			input:    "tags: []",
+			expected: Empty,
+		},
+		"flow": {
+			input:    "tags: [b, a]",
+			expected: NewOf("a", "b"),
+		},
+		"block": {
+			input:    "tags:\n  - b\n  - a\n",
+			expected: NewOf("a", "b"),
+		},
+		"duplicates rejected": {
+			input:       "tags: [a, b, a]",
+			expectedErr: &DuplicateValueError{Value: "a"},
+		},
+		"duplicates allowed": {
+			input:    "tags: [a, b, a]",
+			opts:     YAMLOptions{AllowDuplicates: true},
+			expected: NewOf("a", "b"),
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			var actual struct {
+				Tags YAML `yaml:"tags"`
+			}
+			actual.Tags.Options = c.opts
+			err := yaml.Unmarshal([]byte(c.input), &actual)
+			if c.expectedErr != nil {
+				assert.Equal(t, c.expectedErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, c.expected.IsEqualTo(actual.Tags.Set))
+		})
+	}
+}
+
+func TestCollection_UnmarshalYAMLNotASequence(t *testing.T) {
+	var actual yamlDocument
+	assert.Error(t, yaml.Unmarshal([]byte("tags: {a: b}"), &actual))
+}
@@ -0,0 +1,182 @@
+package string_set_bloom
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"math"
+)
+
+const (
+	// formatVersion is written in the header of MarshalBinary's output
+	formatVersion = 1
+	headerLen     = 4 + 1 + 4 + 8
+	// maxHashes bounds the hash count UnmarshalBinary accepts. New never chooses more than about 1100, even for the
+	// smallest false positive rate a float64 can hold
+	maxHashes = 2048
+)
+
+var (
+	// ErrIncompatible is returned when combining filters that were not created with the same size and hash count
+	ErrIncompatible = errors.New("string_set_bloom: filters have different sizes")
+	// ErrInvalidFormat is returned by UnmarshalBinary when the data is not a filter written by MarshalBinary
+	ErrInvalidFormat = errors.New("string_set_bloom: invalid format")
+)
+
+var magic = [4]byte{'S', 'S', 'B', 'F'}
+
+// New creates an empty filter sized to hold expectedItems items with a false positive rate no greater than
+// falsePositiveRate. falsePositiveRate must be between 0 and 1, exclusive
+func New(expectedItems int, falsePositiveRate float64) *Filter {
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		panic("string_set_bloom: falsePositiveRate must be between 0 and 1, exclusive")
+	}
+	n := float64(max(expectedItems, 1))
+	bits := math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	hashes := max(uint32(math.Round(bits/n*math.Ln2)), 1)
+	return newFilter(uint64(bits), hashes)
+}
+
+// NewOf creates a filter containing items, sized for them and falsePositiveRate. See New
+func NewOf(falsePositiveRate float64, items ...string) *Filter {
+	ret := New(len(items), falsePositiveRate)
+	ret.AddMany(items...)
+	return ret
+}
+
+func newFilter(bits uint64, hashes uint32) *Filter {
+	return &Filter{
+		bits:   make([]uint64, (bits+63)/64),
+		size:   bits,
+		hashes: hashes,
+	}
+}
+
+// Filter is a Bloom filter: a compact, approximate set of strings. It never forgets an item that was added, but it
+// may claim to include an item that was not, at roughly the false positive rate it was created with. Items cannot be
+// removed or listed. Strings are compared byte-for-byte.
+// Please use New, NewOf, or string_set.T.ToBloom to create one
+type Filter struct {
+	bits   []uint64
+	size   uint64
+	hashes uint32
+}
+
+// Add an item to the filter
+func (f *Filter) Add(v string) {
+	h1, h2 := hashOf(v)
+	for i := uint32(0); i < f.hashes; i++ {
+		bit := (h1 + uint64(i)*h2) % f.size
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// AddMany items to the filter
+func (f *Filter) AddMany(v ...string) {
+	for _, s := range v {
+		f.Add(s)
+	}
+}
+
+// MayInclude returns false if v was definitely never added to the filter, and true if it probably was
+func (f *Filter) MayInclude(v string) bool {
+	h1, h2 := hashOf(v)
+	for i := uint32(0); i < f.hashes; i++ {
+		bit := (h1 + uint64(i)*h2) % f.size
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Includes is the same as MayInclude. It lets a Filter stand in for a set behind a string_set.Includer when false
+// positives are acceptable
+func (f *Filter) Includes(v string) bool {
+	return f.MayInclude(v)
+}
+
+// Union returns a new filter that may include everything either filter may include. Both filters must have been
+// created with the same expected number of items and false positive rate, otherwise ErrIncompatible is returned
+func (f *Filter) Union(o *Filter) (*Filter, error) {
+	out := f.Copy()
+	if err := out.Merge(o); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Merge adds everything o may include to this filter. See Union
+func (f *Filter) Merge(o *Filter) error {
+	if f.size != o.size || f.hashes != o.hashes {
+		return ErrIncompatible
+	}
+	for i, word := range o.bits {
+		f.bits[i] |= word
+	}
+	return nil
+}
+
+// Copy returns a shared-nothing copy of the filter
+func (f *Filter) Copy() *Filter {
+	out := newFilter(f.size, f.hashes)
+	copy(out.bits, f.bits)
+	return out
+}
+
+// MarshalBinary encodes the filter so that it can be stored or sent elsewhere and restored with UnmarshalBinary
+func (f *Filter) MarshalBinary() ([]byte, error) {
+	out := make([]byte, headerLen, headerLen+8*len(f.bits))
+	copy(out, magic[:])
+	out[4] = formatVersion
+	binary.BigEndian.PutUint32(out[5:], f.hashes)
+	binary.BigEndian.PutUint64(out[9:], f.size)
+	for _, word := range f.bits {
+		out = binary.BigEndian.AppendUint64(out, word)
+	}
+	return out, nil
+}
+
+// UnmarshalBinary replaces the filter with one encoded by MarshalBinary. Returns ErrInvalidFormat if data is not a
+// filter
+func (f *Filter) UnmarshalBinary(data []byte) error {
+	if len(data) < headerLen || [4]byte(data[:4]) != magic || data[4] != formatVersion {
+		return ErrInvalidFormat
+	}
+	hashes := binary.BigEndian.Uint32(data[5:])
+	size := binary.BigEndian.Uint64(data[9:])
+	words := data[headerLen:]
+	if hashes == 0 || hashes > maxHashes || size == 0 {
+		return ErrInvalidFormat
+	}
+	// (size+63)/64 would overflow for the largest sizes, so count the words from size-1 instead
+	if len(words)%8 != 0 || uint64(len(words)/8) != (size-1)/64+1 {
+		return ErrInvalidFormat
+	}
+	decoded := newFilter(size, hashes)
+	for i := range decoded.bits {
+		decoded.bits[i] = binary.BigEndian.Uint64(words[8*i:])
+	}
+	*f = *decoded
+	return nil
+}
+
+// hashOf returns the two hashes of v that the bit positions are derived from. They are stable across processes, so
+// filters can be shared
+func hashOf(v string) (h1, h2 uint64) {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(v))
+	h1 = mix(h.Sum64())
+	h2 = mix(h1^0x9e3779b97f4a7c15) | 1
+	return
+}
+
+// mix is the finalizer of MurmurHash3, which spreads the bits of FNV's output evenly
+func mix(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
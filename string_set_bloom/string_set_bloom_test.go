@@ -0,0 +1,154 @@
+package string_set_bloom
+
+import (
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestFilter_NoFalseNegatives(t *testing.T) {
+	filter := New(1000, 0.01)
+	for i := 0; i < 1000; i++ {
+		filter.Add(strconv.Itoa(i))
+	}
+	for i := 0; i < 1000; i++ {
+		assert.True(t, filter.MayInclude(strconv.Itoa(i)))
+		assert.True(t, filter.Includes(strconv.Itoa(i)))
+	}
+}
+
+func TestFilter_FalsePositiveRate(t *testing.T) {
+	cases := map[string]struct {
+		rate float64
+	}{
+		"1 percent": {
+			rate: 0.01,
+		},
+		"10 percent": {
+			rate: 0.1,
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			filter := New(10000, c.rate)
+			for i := 0; i < 10000; i++ {
+				filter.Add("in-" + strconv.Itoa(i))
+			}
+			falsePositives := 0
+			for i := 0; i < 100000; i++ {
+				if filter.MayInclude("out-" + strconv.Itoa(i)) {
+					falsePositives++
+				}
+			}
+			assert.Less(t, float64(falsePositives)/100000, c.rate*1.5)
+		})
+	}
+}
+
+func TestFilter_Union(t *testing.T) {
+	a := New(10, 0.01)
+	a.Add("a")
+	b := New(10, 0.01)
+	b.Add("b")
+
+	union, err := a.Union(b)
+	assert.NoError(t, err)
+	assert.True(t, union.MayInclude("a"))
+	assert.True(t, union.MayInclude("b"))
+	assert.False(t, a.MayInclude("b"))
+
+	_, err = a.Union(New(1000, 0.01))
+	assert.Equal(t, ErrIncompatible, err)
+	assert.Equal(t, ErrIncompatible, a.Merge(New(10, 0.5)))
+}
+
+func TestFilter_Binary(t *testing.T) {
+	filter := NewOf(0.01, "a", "b", "c")
+	data, err := filter.MarshalBinary()
+	assert.NoError(t, err)
+
+	decoded := &Filter{}
+	assert.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, filter, decoded)
+	assert.True(t, decoded.MayInclude("b"))
+
+	cases := map[string]struct {
+		data []byte
+	}{
+		"empty": {
+			data: nil,
+		},
+		"bad magic": {
+			data: append([]byte("XXXX"), data[4:]...),
+		},
+		"bad version": {
+			data: append(append([]byte("SSBF"), 99), data[5:]...),
+		},
+		"truncated": {
+			data: data[:len(data)-1],
+		},
+		"extra word": {
+			data: append(slices.Clone(data), make([]byte, 8)...),
+		},
+		"no hashes": {
+			data: withHeader(data, 0, 64),
+		},
+		"too many hashes": {
+			data: withHeader(data, maxHashes+1, 64),
+		},
+		"no bits": {
+			data: withHeader(data[:headerLen], 1, 0),
+		},
+		"largest size without words": {
+			data: withHeader(data[:headerLen], 1, math.MaxUint64),
+		},
+		"largest size with one word": {
+			data: withHeader(data[:headerLen+8], 1, math.MaxUint64),
+		},
+		"size larger than words": {
+			data: withHeader(data[:headerLen+8], 1, 65),
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			assert.Equal(t, ErrInvalidFormat, (&Filter{}).UnmarshalBinary(c.data))
+		})
+	}
+}
+
+// withHeader returns a copy of data with the hash count and size in its header replaced
+func withHeader(data []byte, hashes uint32, size uint64) []byte {
+	out := slices.Clone(data)
+	binary.BigEndian.PutUint32(out[5:], hashes)
+	binary.BigEndian.PutUint64(out[9:], size)
+	return out
+}
+
+func FuzzFilter_UnmarshalBinary(f *testing.F) {
+	data, _ := NewOf(0.01, "a", "b", "c").MarshalBinary()
+	f.Add(data)
+	f.Add(withHeader(data[:headerLen], 1, math.MaxUint64))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		decoded := &Filter{}
+		if decoded.UnmarshalBinary(data) != nil {
+			return
+		}
+		decoded.Add("a")
+		assert.True(t, decoded.MayInclude("a"))
+		encoded, err := decoded.MarshalBinary()
+		assert.NoError(t, err)
+		assert.Len(t, encoded, len(data))
+	})
+}
+
+func TestNew_InvalidRate(t *testing.T) {
+	assert.Panics(t, func() { New(10, 0) })
+	assert.Panics(t, func() { New(10, 1) })
+	assert.NotPanics(t, func() { New(0, 0.5).Add("a") })
+	assert.LessOrEqual(t, New(1, math.SmallestNonzeroFloat64).hashes, uint32(maxHashes))
+}
@@ -0,0 +1,211 @@
+package string_set_compact
+
+import (
+	"github.com/wojnosystems/go-string-set/string_set"
+	"iter"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Empty is a convenience declaration: it's an empty set you can use to compare
+// to other sets if you want to use IsEqualTo instead of testing with Len
+var Empty = NewOf()
+
+// FromSet creates a compact, read-only copy of s
+func FromSet(s string_set.Immutable) *T {
+	return fromUnsorted(s.ToSlice())
+}
+
+// NewOf is a convenience method to create a compact string set containing the items you specify
+func NewOf(items ...string) *T {
+	return fromUnsorted(slices.Clone(items))
+}
+
+// fromUnsorted creates a set from items, which it sorts in place
+func fromUnsorted(items []string) *T {
+	slices.Sort(items)
+	items = slices.Compact(items)
+	size := 0
+	for _, item := range items {
+		size += len(item)
+	}
+	if size > math.MaxUint32 {
+		panic("string_set_compact: items are larger than 4GiB in total")
+	}
+	data := strings.Builder{}
+	data.Grow(size)
+	offsets := make([]uint32, 0, len(items)+1)
+	for _, item := range items {
+		offsets = append(offsets, uint32(data.Len()))
+		data.WriteString(item)
+	}
+	offsets = append(offsets, uint32(data.Len()))
+	return &T{
+		data:    data.String(),
+		offsets: offsets,
+	}
+}
+
+// T holds the underlying string_set_compact type, do not instantiate this yourself,
+// Please use FromSet or NewOf
+//
+// T is a read-only set that stores all of its items in lexical order in a single string, with 4 bytes of overhead
+// per item, instead of the 40 or more bytes a map entry costs. Includes is a binary search, O(log n). It only
+// implements string_set.Immutable. Union, Subtract, Intersection and Copy return a *string_set.T, as T cannot be
+// changed. Strings are compared byte-for-byte
+type T struct {
+	// data holds every item, concatenated in lexical order
+	data string
+	// offsets holds the start of each item in data, followed by the length of data
+	offsets []uint32
+}
+
+// at returns the i-th item in lexical order. The item shares memory with the set
+func (c *T) at(i int) string {
+	return c.data[c.offsets[i]:c.offsets[i+1]]
+}
+
+func (c *T) Includes(v string) bool {
+	i := sort.Search(c.Len(), func(i int) bool {
+		return c.at(i) >= v
+	})
+	return i < c.Len() && c.at(i) == v
+}
+
+func (c *T) IsEmpty() bool {
+	return c.Len() == 0
+}
+
+func (c *T) Len() int {
+	return len(c.offsets) - 1
+}
+
+// IsEqualTo returns true if both sets contain the same strings. If o is also compact, the sets are compared
+// directly
+func (c *T) IsEqualTo(o string_set.Immutable) bool {
+	if other, ok := o.(*T); ok {
+		return c.data == other.data && slices.Equal(c.offsets, other.offsets)
+	}
+	return string_set.IsEqual(c, o)
+}
+
+// Union returns a new, mutable, set containing all of the items from the callee and the parameter
+func (c *T) Union(o string_set.Immutable) (out string_set.Interface) {
+	out = c.Copy()
+	string_set.UnionInto(out, c, o)
+	return
+}
+
+// Subtract returns a new, mutable, set containing only items from the callee, but without the items in the parameter
+func (c *T) Subtract(o string_set.Immutable) (out string_set.Interface) {
+	out = string_set.NewWithCapacity(c.Len())
+	string_set.SubtractInto(out, c, o)
+	return
+}
+
+// Intersection returns a new, mutable, set containing only items common to both the callee and parameter
+func (c *T) Intersection(o string_set.Immutable) (out string_set.Interface) {
+	out = string_set.NewWithCapacity(c.Len())
+	string_set.IntersectionInto(out, c, o)
+	return
+}
+
+// SymmetricDifference returns a new mutable set containing the items of either the callee or the parameter that are
+// not in both, as compared by string_set.CompareKey
+func (c *T) SymmetricDifference(o string_set.Immutable) (out string_set.Interface) {
+	out = string_set.NewWithCapacity(c.Len())
+	string_set.SymmetricDifferenceInto(out, c, o)
+	return
+}
+
+// IsSubsetOf returns true if o contains every item of the set, as compared by string_set.CompareKey
+func (c *T) IsSubsetOf(o string_set.Immutable) bool {
+	return string_set.IsSubset(c, o)
+}
+
+// IsProperSubsetOf returns true if o contains every item of the set and has items the set does not, as compared
+// by string_set.CompareKey
+func (c *T) IsProperSubsetOf(o string_set.Immutable) bool {
+	return string_set.IsProperSubset(c, o)
+}
+
+// IsSupersetOf returns true if the set contains every item of o, as compared by string_set.CompareKey
+func (c *T) IsSupersetOf(o string_set.Immutable) bool {
+	return string_set.IsSubset(o, c)
+}
+
+// IsDisjointFrom returns true if the set and o have no items in common, as compared by string_set.CompareKey
+func (c *T) IsDisjointFrom(o string_set.Immutable) bool {
+	return string_set.IsDisjoint(c, o)
+}
+
+// IntersectionLen returns the number of items Intersection would return, without creating the intersection
+func (c *T) IntersectionLen(o string_set.Immutable) int {
+	return string_set.IntersectionLen(c, o)
+}
+
+// ToSlice returns the items of the set in lexical order. The strings share memory with the set
+func (c *T) ToSlice() (out []string) {
+	out = make([]string, c.Len())
+	for i := range out {
+		out[i] = c.at(i)
+	}
+	return
+}
+
+// Each loops over each string in the set in lexical order
+func (c *T) Each(item func(v string)) {
+	for i := 0; i < c.Len(); i++ {
+		item(c.at(i))
+	}
+}
+
+// EachCancelable is just like Each, but you can stop the iteration by returning
+// string_set.Break instead of string_set.Continue
+func (c *T) EachCancelable(item func(v string) (next string_set.NextAction)) {
+	for i := 0; i < c.Len(); i++ {
+		if item(c.at(i)) == string_set.Break {
+			break
+		}
+	}
+}
+
+// All returns an iterator over the items in the set in lexical order
+func (c *T) All() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for i := 0; i < c.Len(); i++ {
+			if !yield(c.at(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Sorted is the same as All, and is provided so that T can be used wherever the other sets' Sorted is
+func (c *T) Sorted() iter.Seq[string] {
+	return c.All()
+}
+
+// Any returns true if predicate returns true for any item. Short-circuits and stops iteration when didMatch
+// returns true
+func (c *T) Any(item func(v string) (didMatch bool)) bool {
+	for v := range c.All() {
+		if item(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// None return true if predicate returned false for every item in the set. If predicate returns true, short-circuit
+// and return false from this method, indicating that at least 1 item matched
+func (c *T) None(item func(v string) (didMatch bool)) bool {
+	return !c.Any(item)
+}
+
+// Copy returns a mutable *string_set.T containing the items of the set
+func (c *T) Copy() string_set.Interface {
+	return string_set.NewOf(c.ToSlice()...)
+}
@@ -0,0 +1,125 @@
+package string_set_compact
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/wojnosystems/go-string-set/string_set"
+	"runtime"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestCollection_Includes(t *testing.T) {
+	set := NewOf("banana", "apple", "", "cherry", "apple")
+	cases := map[string]struct {
+		input    string
+		expected bool
+	}{
+		"first":          {input: "", expected: true},
+		"middle":         {input: "banana", expected: true},
+		"last":           {input: "cherry", expected: true},
+		"before first":   {input: "0"},
+		"between":        {input: "avocado"},
+		"after last":     {input: "zucchini"},
+		"prefix of item": {input: "app"},
+		"item is prefix": {input: "apples"},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			assert.Equal(t, c.expected, set.Includes(c.input))
+		})
+	}
+	assert.Equal(t, 4, set.Len())
+	assert.False(t, Empty.Includes(""))
+	assert.True(t, Empty.IsEmpty())
+}
+
+func TestCollection_FromSet(t *testing.T) {
+	source := string_set.NewOf("c", "a", "b")
+	set := FromSet(source)
+	source.Add("d")
+
+	assert.Equal(t, []string{"a", "b", "c"}, set.ToSlice())
+	assert.Equal(t, []string{"a", "b", "c"}, slices.Collect(set.All()))
+	assert.True(t, set.IsEqualTo(string_set.NewOf("a", "b", "c")))
+	assert.True(t, string_set.NewOf("a", "b", "c").IsEqualTo(set))
+	assert.True(t, set.IsEqualTo(NewOf("b", "c", "a")))
+	assert.False(t, set.IsEqualTo(NewOf("b", "c")))
+}
+
+func TestCollection_Setter(t *testing.T) {
+	a := NewOf("a", "b", "c")
+	b := string_set.NewOf("b", "c", "d")
+
+	assert.True(t, string_set.NewOf("a", "b", "c", "d").IsEqualTo(a.Union(b)))
+	assert.True(t, string_set.NewOf("a").IsEqualTo(a.Subtract(b)))
+	assert.True(t, string_set.NewOf("b", "c").IsEqualTo(a.Intersection(b)))
+	assert.True(t, string_set.NewOf("a", "d").IsEqualTo(a.SymmetricDifference(b)))
+	assert.Equal(t, 2, a.IntersectionLen(b))
+	assert.True(t, a.IsProperSubsetOf(a.Union(b)))
+	assert.False(t, a.IsDisjointFrom(b))
+
+	copied := a.Copy()
+	copied.Add("x")
+	assert.False(t, a.Includes("x"))
+	assert.True(t, a.Any(func(v string) bool { return v == "b" }))
+	assert.True(t, a.None(func(v string) bool { return v == "x" }))
+
+	var each []string
+	a.EachCancelable(func(v string) string_set.NextAction {
+		each = append(each, v)
+		return string_set.Break
+	})
+	assert.Equal(t, []string{"a"}, each)
+}
+
+// vocabulary returns n distinct words of a typical length
+func vocabulary(n int) []string {
+	words := make([]string, n)
+	for i := range words {
+		words[i] = "word-" + strconv.Itoa(i*7919)
+	}
+	return words
+}
+
+// heapBytesPerItem measures how much the heap grows, per word, when build is called
+func heapBytesPerItem(words []string, build func() interface{}) float64 {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	set := build()
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	runtime.KeepAlive(set)
+	return float64(after.HeapAlloc-before.HeapAlloc) / float64(len(words))
+}
+
+func BenchmarkMemory(b *testing.B) {
+	words := vocabulary(1000000)
+	b.Run("compact", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.ReportMetric(heapBytesPerItem(words, func() interface{} { return NewOf(words...) }), "bytes/item")
+		}
+	})
+	b.Run("string_set", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.ReportMetric(heapBytesPerItem(words, func() interface{} { return string_set.NewOf(words...) }), "bytes/item")
+		}
+	})
+}
+
+func BenchmarkIncludes(b *testing.B) {
+	words := vocabulary(1000000)
+	sets := map[string]string_set.Tester{
+		"compact":    NewOf(words...),
+		"string_set": string_set.NewOf(words...),
+	}
+	for name, set := range sets {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				set.Includes(words[i%len(words)])
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package string_set_hll
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/wojnosystems/go-string-set/string_set"
+	"hash/fnv"
+	"math"
+	"math/bits"
+)
+
+const (
+	// MinPrecision is the smallest precision a sketch can have: 16 registers, about 26% error
+	MinPrecision = 4
+	// MaxPrecision is the largest precision a sketch can have: 262144 registers, about 0.2% error
+	MaxPrecision = 18
+	// DefaultPrecision gives about 0.8% error using 16KiB
+	DefaultPrecision = 14
+
+	// formatVersion is written in the header of MarshalBinary's output
+	formatVersion = 1
+	headerLen     = 4 + 1 + 1 + 1
+	sparseFormat  = 0
+	denseFormat   = 1
+)
+
+var (
+	// ErrIncompatible is returned when merging sketches with different precisions
+	ErrIncompatible = errors.New("string_set_hll: sketches have different precisions")
+	// ErrInvalidFormat is returned by UnmarshalBinary when the data is not a sketch written by MarshalBinary
+	ErrInvalidFormat = errors.New("string_set_hll: invalid format")
+)
+
+var magic = [4]byte{'S', 'S', 'H', 'L'}
+
+// New creates an empty sketch with DefaultPrecision
+func New() *Sketch {
+	return NewWithPrecision(DefaultPrecision)
+}
+
+// NewWithPrecision creates an empty sketch with 2^precision registers. Its typical relative error is
+// 1.04/sqrt(2^precision). precision is clamped to MinPrecision and MaxPrecision
+func NewWithPrecision(precision uint8) *Sketch {
+	precision = min(max(precision, MinPrecision), MaxPrecision)
+	return &Sketch{
+		precision: precision,
+		sparse:    make(map[uint64]bool),
+	}
+}
+
+// NewWithError creates an empty sketch with the smallest precision whose typical relative error is no greater than
+// relativeError, such as 0.01 for 1%
+func NewWithError(relativeError float64) *Sketch {
+	precision := MaxPrecision
+	if relativeError > 0 {
+		precision = int(math.Ceil(2 * math.Log2(1.04/relativeError)))
+	}
+	return NewWithPrecision(uint8(min(max(precision, MinPrecision), MaxPrecision)))
+}
+
+// FromSet creates a sketch with DefaultPrecision containing every item of s. It is exact, however large s is: Len
+// returns s.Len() until the sketch is changed by Add, AddMany or Merge, and then estimates as any sketch does
+func FromSet(s string_set.Immutable) *Sketch {
+	ret := New()
+	s.Each(ret.Add)
+	ret.exactLen = s.Len()
+	return ret
+}
+
+// Sketch is a HyperLogLog sketch, which estimates how many distinct strings were added to it using a fixed amount of
+// memory, however many strings that is. While it has seen only a few strings it remembers their hashes, and its count
+// is exact. Strings are compared byte-for-byte.
+// Please use New, NewWithPrecision, NewWithError or FromSet to create one
+type Sketch struct {
+	precision uint8
+	// sparse holds the hash of each item while there are few enough of them. It is nil once registers is in use
+	sparse    map[uint64]bool
+	registers []uint8
+	// exactLen is the number of items FromSet added, which Len returns instead of an estimate until the sketch
+	// changes. It is 0 otherwise
+	exactLen int
+}
+
+// Add an item to the sketch
+func (c *Sketch) Add(v string) {
+	c.exactLen = 0
+	c.addHash(hashOf(v))
+}
+
+// AddMany items to the sketch
+func (c *Sketch) AddMany(v ...string) {
+	for _, s := range v {
+		c.Add(s)
+	}
+}
+
+// Len returns the estimated number of distinct items added to the sketch. It is exact while IsExact is true
+func (c *Sketch) Len() int {
+	if c.exactLen > 0 {
+		return c.exactLen
+	}
+	if c.sparse != nil {
+		return len(c.sparse)
+	}
+	m := float64(len(c.registers))
+	sum, zeros := 0.0, 0
+	for _, r := range c.registers {
+		sum += 1 / float64(uint64(1)<<r)
+		if r == 0 {
+			zeros++
+		}
+	}
+	estimate := alpha(m) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		// linear counting is more accurate for small cardinalities
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return int(math.Round(estimate))
+}
+
+// IsEmpty returns true if nothing has been added to the sketch
+func (c *Sketch) IsEmpty() bool {
+	return c.Len() == 0
+}
+
+// IsExact returns true while the sketch remembers every hash, or holds just the items of the set it was created
+// from by FromSet, so Len is exact
+func (c *Sketch) IsExact() bool {
+	return c.sparse != nil || c.exactLen > 0
+}
+
+// Precision returns the base 2 logarithm of the number of registers
+func (c *Sketch) Precision() uint8 {
+	return c.precision
+}
+
+// Union returns a new sketch counting everything counted by either sketch. Returns ErrIncompatible if the
+// sketches have different precisions
+func (c *Sketch) Union(o *Sketch) (*Sketch, error) {
+	out := c.Copy()
+	if err := out.Merge(o); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Merge adds everything counted by o to this sketch. See Union
+func (c *Sketch) Merge(o *Sketch) error {
+	if c.precision != o.precision {
+		return ErrIncompatible
+	}
+	c.exactLen = 0
+	if o.sparse != nil {
+		for h := range o.sparse {
+			c.addHash(h)
+		}
+		return nil
+	}
+	c.densify()
+	for i, r := range o.registers {
+		c.registers[i] = max(c.registers[i], r)
+	}
+	return nil
+}
+
+// Copy returns a shared-nothing copy of the sketch
+func (c *Sketch) Copy() *Sketch {
+	out := &Sketch{
+		precision: c.precision,
+		exactLen:  c.exactLen,
+	}
+	if c.sparse != nil {
+		out.sparse = make(map[uint64]bool, len(c.sparse))
+		for h := range c.sparse {
+			out.sparse[h] = true
+		}
+	} else {
+		out.registers = append([]uint8(nil), c.registers...)
+	}
+	return out
+}
+
+// MarshalBinary encodes the sketch so that it can be stored, or sent to be merged elsewhere. The exact count of a
+// sketch created by FromSet is not encoded, so once decoded, Len estimates unless the sketch is sparse
+func (c *Sketch) MarshalBinary() ([]byte, error) {
+	out := append(append([]byte(nil), magic[:]...), formatVersion, c.precision, denseFormat)
+	if c.sparse != nil {
+		out[headerLen-1] = sparseFormat
+		for h := range c.sparse {
+			out = binary.BigEndian.AppendUint64(out, h)
+		}
+		return out, nil
+	}
+	return append(out, c.registers...), nil
+}
+
+// UnmarshalBinary replaces the sketch with one encoded by MarshalBinary. Returns ErrInvalidFormat if data is not a
+// sketch
+func (c *Sketch) UnmarshalBinary(data []byte) error {
+	if len(data) < headerLen || [4]byte(data[:4]) != magic || data[4] != formatVersion {
+		return ErrInvalidFormat
+	}
+	precision, body := data[5], data[headerLen:]
+	if precision < MinPrecision || precision > MaxPrecision {
+		return ErrInvalidFormat
+	}
+	decoded := NewWithPrecision(precision)
+	switch data[6] {
+	case sparseFormat:
+		if len(body)%8 != 0 {
+			return ErrInvalidFormat
+		}
+		for i := 0; i < len(body); i += 8 {
+			decoded.addHash(binary.BigEndian.Uint64(body[i:]))
+		}
+	case denseFormat:
+		if len(body) != 1<<precision {
+			return ErrInvalidFormat
+		}
+		decoded.sparse = nil
+		decoded.registers = append([]uint8(nil), body...)
+	default:
+		return ErrInvalidFormat
+	}
+	*c = *decoded
+	return nil
+}
+
+// addHash records the hash of an item, switching from remembering hashes to registers once there are more than
+// exactLimit of them, so that the memory the hashes use stays bounded
+func (c *Sketch) addHash(h uint64) {
+	if c.sparse != nil {
+		c.sparse[h] = true
+		if len(c.sparse) > exactLimit(c.precision) {
+			c.densify()
+		}
+		return
+	}
+	index := h >> (64 - c.precision)
+	rank := uint8(bits.LeadingZeros64(h<<c.precision|1<<(c.precision-1))) + 1
+	c.registers[index] = max(c.registers[index], rank)
+}
+
+// densify moves the remembered hashes into registers
+func (c *Sketch) densify() {
+	if c.sparse == nil {
+		return
+	}
+	hashes := c.sparse
+	c.sparse = nil
+	c.registers = make([]uint8, 1<<c.precision)
+	for h := range hashes {
+		c.addHash(h)
+	}
+}
+
+// exactLimit returns the most hashes a sketch with the given precision remembers before switching to registers
+func exactLimit(precision uint8) int {
+	return (1 << precision) / 8
+}
+
+// alpha corrects the bias of the raw HyperLogLog estimate for m registers
+func alpha(m float64) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	}
+	return 0.7213 / (1 + 1.079/m)
+}
+
+// hashOf returns a 64-bit hash of v that is stable across processes, so sketches can be merged
+func hashOf(v string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(v))
+	return mix(h.Sum64())
+}
+
+// mix is the finalizer of MurmurHash3, which spreads the bits of FNV's output evenly
+func mix(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
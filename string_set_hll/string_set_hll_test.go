@@ -0,0 +1,154 @@
+package string_set_hll
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/wojnosystems/go-string-set/string_set"
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestSketch_Exact(t *testing.T) {
+	set := string_set.New()
+	for i := 0; i < 1000; i++ {
+		set.Add("user-" + strconv.Itoa(i))
+	}
+	var sketch string_set.Adder = FromSet(set)
+	assert.True(t, sketch.(*Sketch).IsExact())
+	assert.Equal(t, set.Len(), sketch.(*Sketch).Len())
+
+	sketch.AddMany("user-1", "user-2")
+	assert.Equal(t, 1000, sketch.(*Sketch).Len())
+	assert.True(t, New().IsEmpty())
+}
+
+func TestFromSet_ExactLimit(t *testing.T) {
+	set := string_set.New()
+	for i := 0; i < exactLimit(DefaultPrecision); i++ {
+		set.Add(strconv.Itoa(i))
+	}
+	assert.Equal(t, 2048, set.Len())
+	sketch := FromSet(set)
+	assert.True(t, sketch.IsExact())
+	assert.Equal(t, set.Len(), sketch.Len())
+
+	set.Add("one more")
+	assert.True(t, FromSet(set).IsExact())
+}
+
+func TestFromSet_Exact(t *testing.T) {
+	set := string_set.New()
+	for i := 0; i < 100000; i++ {
+		set.Add("user-" + strconv.Itoa(i))
+	}
+	sketch := FromSet(set)
+	assert.True(t, sketch.IsExact())
+	assert.Equal(t, 100000, sketch.Len())
+	assert.Equal(t, 100000, sketch.Copy().Len())
+
+	sketch.Add("user-100000")
+	assert.False(t, sketch.IsExact())
+	assert.InEpsilon(t, 100001, sketch.Len(), 0.03)
+
+	merged := FromSet(set)
+	assert.NoError(t, merged.Merge(New()))
+	assert.False(t, merged.IsExact())
+}
+
+func TestSketch_Estimate(t *testing.T) {
+	cases := map[string]struct {
+		precision uint8
+		distinct  int
+	}{
+		"default precision": {
+			precision: DefaultPrecision,
+			distinct:  200000,
+		},
+		"small precision": {
+			precision: 10,
+			distinct:  50000,
+		},
+		"just past exact": {
+			precision: DefaultPrecision,
+			distinct:  5000,
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			sketch := NewWithPrecision(c.precision)
+			for i := 0; i < c.distinct; i++ {
+				sketch.Add(strconv.Itoa(i))
+				sketch.Add(strconv.Itoa(i / 2))
+			}
+			assert.False(t, sketch.IsExact())
+			expectedError := 1.04 / math.Sqrt(float64(uint64(1)<<c.precision))
+			assert.InEpsilon(t, c.distinct, sketch.Len(), 4*expectedError)
+		})
+	}
+}
+
+func TestSketch_Merge(t *testing.T) {
+	shards := []*Sketch{New(), New(), New()}
+	for i := 0; i < 90000; i++ {
+		// every user is seen by two shards
+		shards[i%3].Add(strconv.Itoa(i))
+		shards[(i+1)%3].Add(strconv.Itoa(i))
+	}
+	total := New()
+	for _, shard := range shards {
+		assert.NoError(t, total.Merge(shard))
+	}
+	assert.InEpsilon(t, 90000, total.Len(), 0.03)
+
+	small, err := NewWithPrecision(8).Union(NewWithPrecision(8))
+	assert.NoError(t, err)
+	assert.True(t, small.IsEmpty())
+
+	exact := New()
+	exact.AddMany("a", "b")
+	other := New()
+	other.AddMany("b", "c")
+	union, err := exact.Union(other)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, union.Len())
+	assert.Equal(t, 2, exact.Len())
+
+	assert.Equal(t, ErrIncompatible, New().Merge(NewWithPrecision(10)))
+}
+
+func TestNewWithError(t *testing.T) {
+	assert.Equal(t, uint8(14), NewWithError(0.01).Precision())
+	assert.Equal(t, uint8(MaxPrecision), NewWithError(0).Precision())
+	assert.Equal(t, uint8(MinPrecision), NewWithError(0.9).Precision())
+}
+
+func TestSketch_Binary(t *testing.T) {
+	cases := map[string]struct {
+		items int
+	}{
+		"sparse": {
+			items: 10,
+		},
+		"dense": {
+			items: 10000,
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			sketch := New()
+			for i := 0; i < c.items; i++ {
+				sketch.Add(strconv.Itoa(i))
+			}
+			data, err := sketch.MarshalBinary()
+			assert.NoError(t, err)
+			decoded := &Sketch{}
+			assert.NoError(t, decoded.UnmarshalBinary(data))
+			assert.Equal(t, sketch.Len(), decoded.Len())
+			assert.Equal(t, sketch.IsExact(), decoded.IsExact())
+			assert.Equal(t, ErrInvalidFormat, decoded.UnmarshalBinary(data[:len(data)-1]))
+		})
+	}
+	assert.Equal(t, ErrInvalidFormat, (&Sketch{}).UnmarshalBinary([]byte("nope")))
+}
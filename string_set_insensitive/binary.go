@@ -0,0 +1,94 @@
+package string_set_insensitive
+
+import (
+	"github.com/wojnosystems/go-string-set/string_set"
+)
+
+// BinaryOptions controls how a case-insensitive set is encoded by Binary
+type BinaryOptions struct {
+	// NormalizedCase encodes each value in its normalized form, which is lower-cased unless the set was created with a
+	// different Normalizer. When false, values are encoded with the spelling they were added with
+	NormalizedCase bool
+	// Unsorted is passed on to string_set.BinaryOptions
+	Unsorted bool
+	// MaxDecodedLen is passed on to string_set.BinaryOptions
+	MaxDecodedLen int
+}
+
+func (o BinaryOptions) shared() string_set.BinaryOptions {
+	return string_set.BinaryOptions{
+		Unsorted:      o.Unsorted,
+		MaxDecodedLen: o.MaxDecodedLen,
+	}
+}
+
+// Binary encodes Set with string_set.EncodeBinary and Options, such as leaving the items unsorted. Use it in
+// place of the set, including with encoding/gob, when the defaults of T.MarshalBinary do not suit. A nil Set is
+// encoded as an empty set, and decoded into a new set created by New
+type Binary struct {
+	Set     *T
+	Options BinaryOptions
+}
+
+// MarshalBinary encodes the set with string_set.EncodeBinary. Each value keeps the spelling it was added with unless
+// Options.NormalizedCase is set
+func (b Binary) MarshalBinary() ([]byte, error) {
+	return string_set.EncodeBinary(encoded(b.Set, b.Options.NormalizedCase), b.Options.shared())
+}
+
+// UnmarshalBinary replaces the contents of the set with the strings encoded by MarshalBinary. The set is left
+// unchanged if data is invalid, corrupted or too large
+func (b *Binary) UnmarshalBinary(data []byte) error {
+	decoded := emptyLike(b.Set)
+	if err := string_set.DecodeBinary(data, decoded, b.Options.shared()); err != nil {
+		return err
+	}
+	b.Set = replaced(b.Set, decoded)
+	return nil
+}
+
+// MarshalBinary encodes the original spellings of the set with string_set.EncodeBinary, sorted and front-coded.
+// Use Binary to choose other options
+func (c *T) MarshalBinary() ([]byte, error) {
+	return Binary{Set: c}.MarshalBinary()
+}
+
+// UnmarshalBinary replaces the contents of the set with the strings encoded by MarshalBinary. A zero-value T may be
+// used. The set is left unchanged if data is invalid or corrupted
+func (c *T) UnmarshalBinary(data []byte) error {
+	return (&Binary{Set: c}).UnmarshalBinary(data)
+}
+
+// MarshalBinary encodes the original spellings of the snapshot with string_set.EncodeBinary, sorted and front-coded
+func (f *Frozen) MarshalBinary() ([]byte, error) {
+	return f.t.MarshalBinary()
+}
+
+// encoded returns what is encoded for c: its normalized strings if normalizedCase is set, or else its original
+// spellings. A nil c is encoded as an empty set
+func encoded(c *T, normalizedCase bool) string_set.Immutable {
+	switch {
+	case c == nil:
+		return string_set.Empty
+	case normalizedCase:
+		return &c.T
+	}
+	return c
+}
+
+// emptyLike returns a new, empty set to decode into, configured like c, or created by New if c is nil
+func emptyLike(c *T) *T {
+	if c == nil {
+		return NewWithCapacity(0)
+	}
+	return c.empty(0)
+}
+
+// replaced returns c with its contents replaced by those of decoded, or decoded itself if c is nil
+func replaced(c, decoded *T) *T {
+	if c == nil {
+		return decoded
+	}
+	*c = *decoded
+	return c
+}
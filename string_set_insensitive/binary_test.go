@@ -0,0 +1,54 @@
+package string_set_insensitive
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/wojnosystems/go-string-set/string_set"
+	"slices"
+	"testing"
+)
+
+func TestCollection_BinaryRoundTrip(t *testing.T) {
+	cases := map[string]struct {
+		opts     BinaryOptions
+		expected []string
+	}{
+		"original case": {
+			expected: []string{"Apple", "BANANA"},
+		},
+		"original case unsorted": {
+			opts:     BinaryOptions{Unsorted: true},
+			expected: []string{"Apple", "BANANA"},
+		},
+		"normalized": {
+			opts:     BinaryOptions{NormalizedCase: true},
+			expected: []string{"apple", "banana"},
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			data, err := Binary{Set: NewOf("Apple", "BANANA"), Options: c.opts}.MarshalBinary()
+			assert.NoError(t, err)
+
+			actual := &T{}
+			assert.NoError(t, actual.UnmarshalBinary(data))
+			assert.Equal(t, c.expected, slices.Collect(actual.Sorted()))
+			assert.True(t, actual.Includes("APPLE"))
+		})
+	}
+}
+
+func TestCollection_UnmarshalBinaryKeepsOptions(t *testing.T) {
+	data, _ := NewOf("Apple").MarshalBinary()
+	actual := NewWithOptions(Options{Normalizer: Fold})
+	assert.NoError(t, actual.UnmarshalBinary(data))
+
+	again, _ := actual.MarshalBinary()
+	decoded := string_set.New()
+	assert.NoError(t, decoded.UnmarshalBinary(again))
+	assert.True(t, decoded.IsEqualTo(string_set.NewOf("Apple")))
+	assert.Equal(t, "apple", actual.Normalize("APPLE"))
+
+	assert.Equal(t, string_set.ErrChecksumMismatch, actual.UnmarshalBinary(data[:len(data)-1]))
+	assert.True(t, actual.Includes("apple"))
+}
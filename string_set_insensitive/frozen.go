@@ -0,0 +1,118 @@
+package string_set_insensitive
+
+import (
+	"github.com/wojnosystems/go-string-set/string_set"
+	"iter"
+)
+
+// Frozen is a read-only, case-insensitive snapshot of a set. It only implements string_set.Immutable and holds no
+// reference to the set it was created from.
+// Please use T.Freeze to create one
+type Frozen struct {
+	t T
+}
+
+// Freeze returns a read-only snapshot of the set. Later changes to the callee are not reflected in the snapshot
+func (c *T) Freeze() *Frozen {
+	return &Frozen{
+		t: *c.Copy().(*T),
+	}
+}
+
+func (f *Frozen) Includes(v string) bool {
+	return f.t.Includes(v)
+}
+
+func (f *Frozen) IsEmpty() bool {
+	return f.t.IsEmpty()
+}
+
+func (f *Frozen) Len() int {
+	return f.t.Len()
+}
+
+func (f *Frozen) IsEqualTo(o string_set.Immutable) bool {
+	return f.t.IsEqualTo(o)
+}
+
+func (f *Frozen) Union(o string_set.Immutable) string_set.Interface {
+	return f.t.Union(o)
+}
+
+func (f *Frozen) Subtract(o string_set.Immutable) string_set.Interface {
+	return f.t.Subtract(o)
+}
+
+func (f *Frozen) Intersection(o string_set.Immutable) string_set.Interface {
+	return f.t.Intersection(o)
+}
+
+func (f *Frozen) SymmetricDifference(o string_set.Immutable) string_set.Interface {
+	return f.t.SymmetricDifference(o)
+}
+
+func (f *Frozen) IsSubsetOf(o string_set.Immutable) bool {
+	return f.t.IsSubsetOf(o)
+}
+
+func (f *Frozen) IsProperSubsetOf(o string_set.Immutable) bool {
+	return f.t.IsProperSubsetOf(o)
+}
+
+func (f *Frozen) IsSupersetOf(o string_set.Immutable) bool {
+	return f.t.IsSupersetOf(o)
+}
+
+func (f *Frozen) IsDisjointFrom(o string_set.Immutable) bool {
+	return f.t.IsDisjointFrom(o)
+}
+
+func (f *Frozen) IntersectionLen(o string_set.Immutable) int {
+	return f.t.IntersectionLen(o)
+}
+
+func (f *Frozen) ToSlice() []string {
+	return f.t.ToSlice()
+}
+
+func (f *Frozen) Each(item func(v string)) {
+	f.t.Each(item)
+}
+
+func (f *Frozen) EachCancelable(item func(v string) (next string_set.NextAction)) {
+	f.t.EachCancelable(item)
+}
+
+func (f *Frozen) All() iter.Seq[string] {
+	return f.t.All()
+}
+
+// Sorted returns an iterator over the original spellings of the items of the snapshot in lexical order
+func (f *Frozen) Sorted() iter.Seq[string] {
+	return f.t.Sorted()
+}
+
+// Normalize returns the form of v that is used to compare it. See T.Normalize
+func (f *Frozen) Normalize(v string) string {
+	return f.t.Normalize(v)
+}
+
+// Original returns the spelling v was added with, and true. If v is not in the snapshot, returns "" and false
+func (f *Frozen) Original(v string) (string, bool) {
+	return f.t.Original(v)
+}
+
+// Any returns true if predicate returns true for any item. See T.Any
+func (f *Frozen) Any(item func(v string, converter func(in string) string) (didMatch bool)) bool {
+	return f.t.Any(item)
+}
+
+// None returns true if predicate returned false for every item in the set. See T.None
+func (f *Frozen) None(item func(v string, converter func(in string) string) (didMatch bool)) bool {
+	return f.t.None(item)
+}
+
+// Copy returns a mutable, case-insensitive copy of the snapshot
+func (f *Frozen) Copy() string_set.Interface {
+	return f.t.Copy()
+}
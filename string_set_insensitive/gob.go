@@ -0,0 +1,18 @@
+package string_set_insensitive
+
+// GobEncode encodes the original spellings of the set for encoding/gob in the format of string_set.EncodeBinary.
+// They are always sorted, so that sets with the same spellings encode to the same bytes. Use Binary to encode the
+// normalized strings
+func (c *T) GobEncode() ([]byte, error) {
+	return c.MarshalBinary()
+}
+
+// GobDecode replaces the contents of the set with the strings encoded by GobEncode. A zero-value T may be used
+func (c *T) GobDecode(data []byte) error {
+	return c.UnmarshalBinary(data)
+}
+
+// GobEncode encodes the original spellings of the snapshot for encoding/gob, sorted
+func (f *Frozen) GobEncode() ([]byte, error) {
+	return f.t.GobEncode()
+}
@@ -0,0 +1,46 @@
+package string_set_insensitive
+
+import (
+	"bytes"
+	"encoding/gob"
+	"github.com/stretchr/testify/assert"
+	"slices"
+	"testing"
+)
+
+func TestCollection_Gob(t *testing.T) {
+	cases := map[string]struct {
+		opts     BinaryOptions
+		expected []string
+	}{
+		"original case": {
+			opts:     BinaryOptions{Unsorted: true},
+			expected: []string{"A", "b"},
+		},
+		"normalized": {
+			opts:     BinaryOptions{NormalizedCase: true},
+			expected: []string{"a", "b"},
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			buffer := bytes.Buffer{}
+			assert.NoError(t, gob.NewEncoder(&buffer).Encode(Binary{Set: NewOf("b", "A"), Options: c.opts}))
+
+			actual := &Binary{}
+			assert.NoError(t, gob.NewDecoder(&buffer).Decode(actual))
+			assert.Equal(t, c.expected, slices.Collect(actual.Set.Sorted()))
+		})
+	}
+}
+
+func TestCollection_GobEncode(t *testing.T) {
+	buffer := bytes.Buffer{}
+	assert.NoError(t, gob.NewEncoder(&buffer).Encode(NewOf("b", "A")))
+
+	actual := &T{}
+	assert.NoError(t, gob.NewDecoder(&buffer).Decode(actual))
+	assert.Equal(t, []string{"A", "b"}, slices.Collect(actual.Sorted()))
+	assert.True(t, actual.Includes("B"))
+}
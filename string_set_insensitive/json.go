@@ -0,0 +1,65 @@
+package string_set_insensitive
+
+import (
+	"github.com/wojnosystems/go-string-set/string_set"
+)
+
+// JSONOptions controls how a case-insensitive set is encoded to and decoded from JSON
+type JSONOptions struct {
+	// NormalizedCase emits each value in its normalized form, which is lower-cased unless the set was created with a
+	// different Normalizer. When false, values are emitted with the spelling they were added with
+	NormalizedCase bool
+	// AllowDuplicates tolerates a value appearing more than once in the JSON array, ignoring case. When false,
+	// decoding ["a", "A"] fails with a *string_set.DuplicateValueError
+	AllowDuplicates bool
+}
+
+// JSON encodes and decodes Set as a JSON array with Options, such as normalizing the strings. Use it in
+// place of the set, as a struct field or as the argument to json.Marshal and json.Unmarshal, when the defaults of
+// T.MarshalJSON and T.UnmarshalJSON do not suit. A nil Set is encoded as an empty array, and decoded into a new set
+// created by New
+type JSON struct {
+	Set     *T
+	Options JSONOptions
+}
+
+// MarshalJSON encodes the set as a JSON array of strings in lexical order. Each value keeps the spelling it
+// was added with unless Options.NormalizedCase is set
+func (j JSON) MarshalJSON() ([]byte, error) {
+	return string_set.EncodeJSON(encoded(j.Set, j.Options.NormalizedCase))
+}
+
+// UnmarshalJSON replaces the contents of the set with the strings in a JSON array. JSON null leaves the set
+// unchanged. Values that differ only by case are duplicates and are rejected unless Options allows them
+func (j *JSON) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	decoded := emptyLike(j.Set)
+	err := string_set.DecodeJSON(data, decoded, string_set.JSONOptions{
+		AllowDuplicates: j.Options.AllowDuplicates,
+	})
+	if err != nil {
+		return err
+	}
+	j.Set = replaced(j.Set, decoded)
+	return nil
+}
+
+// MarshalJSON encodes the original spellings of the set as a JSON array in lexical order. Use JSON to encode the
+// normalized strings instead
+func (c *T) MarshalJSON() ([]byte, error) {
+	return JSON{Set: c}.MarshalJSON()
+}
+
+// UnmarshalJSON replaces the contents of the set with the strings in a JSON array. A zero-value T may be used, and
+// JSON null leaves the set unchanged. Values that differ only by case are duplicates and are rejected; use JSON to
+// allow them
+func (c *T) UnmarshalJSON(data []byte) error {
+	return (&JSON{Set: c}).UnmarshalJSON(data)
+}
+
+// MarshalJSON encodes the original spellings of the snapshot as a JSON array in lexical order
+func (f *Frozen) MarshalJSON() ([]byte, error) {
+	return f.t.MarshalJSON()
+}
@@ -0,0 +1,77 @@
+package string_set_insensitive
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/wojnosystems/go-string-set/string_set"
+	"testing"
+)
+
+func TestCollection_MarshalJSON(t *testing.T) {
+	actual, err := json.Marshal(NewOf("c", "A", "b"))
+	assert.NoError(t, err)
+	assert.Equal(t, `["A","b","c"]`, string(actual))
+
+	actual, err = json.Marshal(&T{})
+	assert.NoError(t, err)
+	assert.Equal(t, `[]`, string(actual))
+}
+
+func TestCollection_UnmarshalJSON(t *testing.T) {
+	cases := map[string]struct {
+		input       string
+		opts        JSONOptions
+		expected    string_set.Immutable
+		expectedErr error
+	}{
+		"items": {
+			input:    `["B","a"]`,
+			expected: NewOf("a", "b"),
+		},
+		"duplicates rejected ignoring case": {
+			input:       `["a","b","A"]`,
+			expectedErr: &string_set.DuplicateValueError{Value: "A"},
+		},
+		"duplicates allowed": {
+			input:    `["a","b","A"]`,
+			opts:     JSONOptions{AllowDuplicates: true},
+			expected: NewOf("a", "b"),
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			actual := &JSON{Options: c.opts}
+			err := json.Unmarshal([]byte(c.input), actual)
+			if c.expectedErr != nil {
+				assert.Equal(t, c.expectedErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, c.expected.IsEqualTo(actual.Set))
+			assert.True(t, actual.Set.Includes("A"))
+		})
+	}
+}
+
+func TestCollection_MarshalJSONNormalizedCase(t *testing.T) {
+	set := NewOf("c", "A", "b")
+	actual, err := json.Marshal(JSON{Set: set, Options: JSONOptions{NormalizedCase: true}})
+	assert.NoError(t, err)
+	assert.Equal(t, `["a","b","c"]`, string(actual))
+
+	actual, err = json.Marshal(set.Freeze())
+	assert.NoError(t, err)
+	assert.Equal(t, `["A","b","c"]`, string(actual))
+
+	decoded := &T{}
+	assert.NoError(t, json.Unmarshal(actual, decoded))
+	original, _ := decoded.Original("a")
+	assert.Equal(t, "A", original)
+}
+
+func TestCollection_UnmarshalJSONNullIsNoOp(t *testing.T) {
+	set := NewOf("A")
+	assert.NoError(t, json.Unmarshal([]byte(`null`), set))
+	assert.Equal(t, []string{"A"}, set.ToSlice())
+}
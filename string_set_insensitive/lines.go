@@ -0,0 +1,93 @@
+package string_set_insensitive
+
+import (
+	"github.com/wojnosystems/go-string-set/string_set"
+	"io"
+)
+
+// LineOptions controls how a case-insensitive set is read from and written to text with one item per line. See
+// string_set.LineOptions
+type LineOptions struct {
+	// CommentPrefix starts a comment line. Defaults to string_set.DefaultLineCommentPrefix
+	CommentPrefix string
+	// NoComments reads every line as an item, even ones starting with CommentPrefix
+	NoComments bool
+	// KeepSpace keeps whitespace at the start and end of each line as part of the item
+	KeepSpace bool
+	// MaxLineLength is the longest line, in bytes, that is accepted. Defaults to string_set.DefaultMaxLineLength
+	MaxLineLength int
+	// NormalizedCase writes each value in its normalized form, which is lower-cased unless the set was created with a
+	// different Normalizer. When false, values are written with the spelling they were added with
+	NormalizedCase bool
+	// AllowDuplicates tolerates a value appearing on more than one line, ignoring case. When false, reading a
+	// repeated value fails with a *string_set.DuplicateValueError
+	AllowDuplicates bool
+}
+
+func (o LineOptions) shared() string_set.LineOptions {
+	return string_set.LineOptions{
+		CommentPrefix:   o.CommentPrefix,
+		NoComments:      o.NoComments,
+		KeepSpace:       o.KeepSpace,
+		MaxLineLength:   o.MaxLineLength,
+		AllowDuplicates: o.AllowDuplicates,
+	}
+}
+
+// ReadFrom reads a new set from text with one item per line. Case-insensitive
+func ReadFrom(r io.Reader, opts LineOptions) (*T, error) {
+	out := New()
+	if _, err := string_set.DecodeLines(r, out, opts.shared()); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Lines reads and writes Set as text with one item per line, using Options. Use it in place of the set when the
+// defaults of T.ReadFrom and T.WriteTo do not suit. A nil Set is written as no lines, and read into a new set
+// created by New
+type Lines struct {
+	Set     *T
+	Options LineOptions
+}
+
+// ReadFrom implements io.ReaderFrom, adding the item on each line read from r to the set. Values within r that
+// differ only by case are rejected unless Options allows them, but values already in the set are not. The set is
+// left unchanged if an error is returned
+func (l *Lines) ReadFrom(r io.Reader) (n int64, err error) {
+	decoded := emptyLike(l.Set)
+	if n, err = string_set.DecodeLines(r, decoded, l.Options.shared()); err != nil {
+		return
+	}
+	if l.Set == nil {
+		l.Set = decoded
+		return
+	}
+	decoded.Each(l.Set.Add)
+	return
+}
+
+// WriteTo implements io.WriterTo, writing each item of the set on its own line in lexical order. The strings are
+// written with the spelling they were added with unless Options.NormalizedCase is set
+func (l Lines) WriteTo(w io.Writer) (n int64, err error) {
+	return string_set.EncodeLines(w, encoded(l.Set, l.Options.NormalizedCase), l.Options.shared())
+}
+
+// ReadFrom implements io.ReaderFrom, adding the item on each line read from r to the set, with the default
+// LineOptions. Values within r that differ only by case are rejected, but values already in the set are not. A
+// zero-value T may be used. The set is left unchanged if an error is returned. Use Lines or the ReadFrom function
+// to choose options
+func (c *T) ReadFrom(r io.Reader) (n int64, err error) {
+	return (&Lines{Set: c}).ReadFrom(r)
+}
+
+// WriteTo implements io.WriterTo, writing the original spellings of the set, each on its own line, in lexical order
+func (c *T) WriteTo(w io.Writer) (n int64, err error) {
+	return Lines{Set: c}.WriteTo(w)
+}
+
+// WriteTo implements io.WriterTo, writing the original spellings of the snapshot, each on its own line, in lexical
+// order
+func (f *Frozen) WriteTo(w io.Writer) (n int64, err error) {
+	return f.t.WriteTo(w)
+}
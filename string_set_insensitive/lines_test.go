@@ -0,0 +1,65 @@
+package string_set_insensitive
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/wojnosystems/go-string-set/string_set"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReadFrom(t *testing.T) {
+	actual, err := ReadFrom(strings.NewReader("# hosts\r\nExample.com\r\n\r\n  example.ORG  \r\n"), LineOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Example.com", "example.ORG"}, slices.Collect(actual.Sorted()))
+	assert.True(t, actual.Includes("EXAMPLE.COM"))
+
+	_, err = ReadFrom(strings.NewReader("a\nb\nA\n"), LineOptions{})
+	assert.Equal(t, &string_set.LineError{Line: 3, Err: &string_set.DuplicateValueError{Value: "A"}}, err)
+
+	_, err = ReadFrom(strings.NewReader("a\nb\nA\n"), LineOptions{AllowDuplicates: true})
+	assert.NoError(t, err)
+}
+
+func TestCollection_ReadFrom(t *testing.T) {
+	set := NewOf("A")
+	_, err := set.ReadFrom(strings.NewReader("a\nB\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A", "B"}, slices.Collect(set.Sorted()))
+
+	zero := &T{}
+	_, err = zero.ReadFrom(strings.NewReader("B\n"))
+	assert.NoError(t, err)
+	actual := bytes.Buffer{}
+	_, err = zero.WriteTo(&actual)
+	assert.NoError(t, err)
+	assert.Equal(t, "B\n", actual.String())
+}
+
+func TestCollection_WriteTo(t *testing.T) {
+	cases := map[string]struct {
+		opts     LineOptions
+		expected string
+	}{
+		"original case": {
+			expected: "A\nb\n",
+		},
+		"normalized": {
+			opts:     LineOptions{NormalizedCase: true},
+			expected: "a\nb\n",
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			actual := bytes.Buffer{}
+			_, err := Lines{Set: NewOf("b", "A"), Options: c.opts}.WriteTo(&actual)
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, actual.String())
+		})
+	}
+
+	_, err := NewOf("#A").WriteTo(&bytes.Buffer{})
+	assert.ErrorIs(t, err, string_set.ErrUnwritableItem)
+}
@@ -0,0 +1,68 @@
+package string_set_insensitive
+
+import (
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"strings"
+	"unicode"
+)
+
+// Normalizer converts a value to the form used to compare it. Two values are equal when their normalized forms are
+// byte-for-byte equal. Normalizers must be safe for concurrent use and should be idempotent
+type Normalizer func(v string) string
+
+// Lower compares values by strings.ToLower. It is the default and is fast, but it does not treat "ß" and "SS" as
+// equal, and does not understand composed and decomposed accents
+func Lower(v string) string {
+	return strings.ToLower(v)
+}
+
+// Fold compares values by full Unicode case folding, so "ß" equals "SS" and the Greek final sigma "ς" equals "σ"
+func Fold(v string) string {
+	// Casers hold state, so one can't be shared between goroutines
+	return cases.Fold().String(v)
+}
+
+// TurkishLower lower-cases values using Turkish rules, so "I" equals "ı" and "İ" equals "i"
+func TurkishLower(v string) string {
+	return cases.Lower(language.Turkish).String(v)
+}
+
+// NFC converts values to Unicode Normalization Form C, so composed and decomposed accents are equal
+func NFC(v string) string {
+	return norm.NFC.String(v)
+}
+
+// NFKC converts values to Unicode Normalization Form KC, which also equates compatibility characters such as "ﬁ"
+// and "fi", or full-width and regular digits
+func NFKC(v string) string {
+	return norm.NFKC.String(v)
+}
+
+// StripAccents removes combining marks, so "é" equals "e"
+func StripAccents(v string) string {
+	stripped, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), v)
+	if err != nil {
+		return v
+	}
+	return stripped
+}
+
+// TrimSpace removes leading and trailing white space
+func TrimSpace(v string) string {
+	return strings.TrimSpace(v)
+}
+
+// Chain returns a Normalizer that applies each of normalizers in order. For user-entered names, a good choice is
+// Chain(TrimSpace, Fold, NFC)
+func Chain(normalizers ...Normalizer) Normalizer {
+	return func(v string) string {
+		for _, normalize := range normalizers {
+			v = normalize(v)
+		}
+		return v
+	}
+}
@@ -0,0 +1,117 @@
+package string_set_insensitive
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/wojnosystems/go-string-set/string_set"
+	"strings"
+	"testing"
+)
+
+func TestNormalizer(t *testing.T) {
+	cases := map[string]struct {
+		normalizer Normalizer
+		a          string
+		b          string
+		expected   bool
+	}{
+		"lower ignores case": {
+			normalizer: Lower,
+			a:          "McDonald",
+			b:          "MCDONALD",
+			expected:   true,
+		},
+		"lower does not fold sharp s": {
+			normalizer: Lower,
+			a:          "straße",
+			b:          "STRASSE",
+		},
+		"fold sharp s": {
+			normalizer: Fold,
+			a:          "straße",
+			b:          "STRASSE",
+			expected:   true,
+		},
+		"fold greek final sigma": {
+			normalizer: Fold,
+			a:          "ΟΔΟΣ",
+			b:          "οδος",
+			expected:   true,
+		},
+		"turkish dotted i": {
+			normalizer: TurkishLower,
+			a:          "İstanbul",
+			b:          "istanbul",
+			expected:   true,
+		},
+		"turkish dotless i": {
+			normalizer: TurkishLower,
+			a:          "ISPARTA",
+			b:          "isparta",
+		},
+		"nfc composed and decomposed": {
+			normalizer: NFC,
+			a:          "café",
+			b:          "café",
+			expected:   true,
+		},
+		"nfkc ligature": {
+			normalizer: NFKC,
+			a:          "ﬁle",
+			b:          "file",
+			expected:   true,
+		},
+		"strip accents": {
+			normalizer: StripAccents,
+			a:          "café",
+			b:          "cafe",
+			expected:   true,
+		},
+		"trim space": {
+			normalizer: TrimSpace,
+			a:          " tag\t",
+			b:          "tag",
+			expected:   true,
+		},
+		"chain": {
+			normalizer: Chain(TrimSpace, Fold, NFC),
+			a:          " Café STRASSE ",
+			b:          "café straße",
+			expected:   true,
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			set := NewWithOptions(Options{Normalizer: c.normalizer})
+			set.Add(c.a)
+			assert.Equal(t, c.expected, set.Includes(c.b))
+			original, _ := set.Original(c.b)
+			if c.expected {
+				assert.Equal(t, c.a, original)
+			}
+		})
+	}
+}
+
+func TestNormalizer_KeptByOperations(t *testing.T) {
+	set := NewWithOptions(Options{Normalizer: Fold})
+	set.Add("straße")
+
+	assert.True(t, set.Copy().Includes("STRASSE"))
+	assert.True(t, set.Freeze().Includes("STRASSE"))
+	assert.True(t, set.Union(NewOf("x")).Includes("STRASSE"))
+	assert.True(t, set.Intersection(NewOf("STRASSE")).Includes("strasse"))
+	assert.True(t, set.Any(func(v string, convert func(in string) string) bool {
+		return v == convert("STRASSE")
+	}))
+}
+
+func TestNormalizer_MixedWithNormalizedSet(t *testing.T) {
+	trimmed := string_set.NewNormalizedOf(strings.TrimSpace, " McDonald ", "Smith")
+	folded := NewOf("MCDONALD", "jones")
+
+	assert.Equal(t, "mcdonald", string_set.CompareKey(trimmed, folded)(" McDonald "))
+	assert.Equal(t, []string{"McDonald"}, trimmed.Intersection(folded).ToSlice())
+	assert.Equal(t, []string{"Smith"}, trimmed.Subtract(folded).ToSlice())
+	assert.Equal(t, 3, trimmed.Union(folded).Len())
+}
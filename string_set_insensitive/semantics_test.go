@@ -0,0 +1,228 @@
+package string_set_insensitive
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/wojnosystems/go-string-set/string_set"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+// words are the strings the property tests build sets from. They differ by case and surrounding white space so that
+// sets which compare strings differently disagree about them
+var words = []string{"a", "A", " a", "b", "B", "b ", "c", "ß", "SS", ""}
+
+// kinds create each type of set with a different way of comparing strings
+var kinds = map[string]func(items ...string) string_set.Interface{
+	"sensitive": func(items ...string) string_set.Interface {
+		return string_set.NewOf(items...)
+	},
+	"insensitive": func(items ...string) string_set.Interface {
+		return NewOf(items...)
+	},
+	"insensitive folded": func(items ...string) string_set.Interface {
+		set := NewWithOptions(Options{Normalizer: Fold})
+		set.AddMany(items...)
+		return set
+	},
+	"trimmed": func(items ...string) string_set.Interface {
+		return string_set.NewNormalizedOf(strings.TrimSpace, items...)
+	},
+}
+
+// wordsOf picks words using the random bytes quick generates
+func wordsOf(picks []uint8) (out []string) {
+	for _, p := range picks {
+		out = append(out, words[int(p)%len(words)])
+	}
+	return
+}
+
+func TestSemantics_Properties(t *testing.T) {
+	properties := map[string]struct {
+		// sameKindOnly properties only hold when every operand compares strings the same way
+		sameKindOnly bool
+		holds        func(a, b, c string_set.Interface) bool
+	}{
+		"equality is symmetric": {
+			holds: func(a, b, _ string_set.Interface) bool {
+				return a.IsEqualTo(b) == b.IsEqualTo(a)
+			},
+		},
+		"equal to copy": {
+			holds: func(a, _, _ string_set.Interface) bool {
+				return a.IsEqualTo(a.Copy()) && a.Copy().IsEqualTo(a)
+			},
+		},
+		"union is commutative": {
+			holds: func(a, b, _ string_set.Interface) bool {
+				return a.Union(b).IsEqualTo(b.Union(a))
+			},
+		},
+		"intersection is commutative": {
+			holds: func(a, b, _ string_set.Interface) bool {
+				return a.Intersection(b).IsEqualTo(b.Intersection(a))
+			},
+		},
+		"difference shares nothing with the subtrahend": {
+			holds: func(a, b, _ string_set.Interface) bool {
+				return a.Subtract(b).Intersection(b).IsEmpty() && b.Intersection(a.Subtract(b)).IsEmpty()
+			},
+		},
+		"difference and intersection partition the set": {
+			holds: func(a, b, _ string_set.Interface) bool {
+				return a.Subtract(b).Union(a.Intersection(b)).IsEqualTo(a)
+			},
+		},
+		"union contains both operands": {
+			holds: func(a, b, _ string_set.Interface) bool {
+				union := a.Union(b)
+				return a.Subtract(union).IsEmpty() && b.Subtract(union).IsEmpty()
+			},
+		},
+		"symmetric difference is commutative": {
+			holds: func(a, b, _ string_set.Interface) bool {
+				return a.SymmetricDifference(b).IsEqualTo(b.SymmetricDifference(a))
+			},
+		},
+		// when several items of b have the same key, which one the union keeps is unspecified, and a's type may not
+		// consider those items the same, so this only holds when a and b compare strings the same way
+		"symmetric difference is the union without the intersection": {
+			sameKindOnly: true,
+			holds: func(a, b, _ string_set.Interface) bool {
+				return a.SymmetricDifference(b).IsEqualTo(a.Union(b).Subtract(a.Intersection(b)))
+			},
+		},
+		"subset and superset agree": {
+			holds: func(a, b, _ string_set.Interface) bool {
+				return a.IsSubsetOf(b) == b.IsSupersetOf(a)
+			},
+		},
+		"proper subset is a subset that is not equal": {
+			holds: func(a, b, _ string_set.Interface) bool {
+				return a.IsProperSubsetOf(b) == (a.IsSubsetOf(b) && !a.IsEqualTo(b))
+			},
+		},
+		"operands are subsets of the union": {
+			holds: func(a, b, _ string_set.Interface) bool {
+				union := a.Union(b)
+				return a.IsSubsetOf(union) && b.IsSubsetOf(union) && union.IsSupersetOf(a)
+			},
+		},
+		"subset means nothing left to subtract": {
+			holds: func(a, b, _ string_set.Interface) bool {
+				return a.IsSubsetOf(b) == a.Subtract(b).IsEmpty()
+			},
+		},
+		"disjoint is symmetric": {
+			holds: func(a, b, _ string_set.Interface) bool {
+				return a.IsDisjointFrom(b) == b.IsDisjointFrom(a)
+			},
+		},
+		"disjoint means an empty intersection": {
+			holds: func(a, b, _ string_set.Interface) bool {
+				return a.IsDisjointFrom(b) == a.Intersection(b).IsEmpty()
+			},
+		},
+		"intersection length is the length of the intersection": {
+			holds: func(a, b, _ string_set.Interface) bool {
+				return a.IntersectionLen(b) == a.Intersection(b).Len()
+			},
+		},
+		"in-place subtract and intersect match their copying counterparts": {
+			holds: func(a, b, _ string_set.Interface) bool {
+				subtract, intersection := a.Copy(), a.Copy()
+				return subtract.SubtractWith(b) == a.Len()-subtract.Len() && subtract.IsEqualTo(a.Subtract(b)) &&
+					intersection.IntersectWith(b) == a.Len()-intersection.Len() &&
+					intersection.IsEqualTo(a.Intersection(b))
+			},
+		},
+		"in-place union contains both operands": {
+			holds: func(a, b, _ string_set.Interface) bool {
+				union := a.Copy()
+				return union.UnionWith(b) == union.Len()-a.Len() && a.IsSubsetOf(union) && b.IsSubsetOf(union)
+			},
+		},
+		// which of several items of b with the same key is added is unspecified, as with the symmetric difference
+		// above, so this only holds when a and b compare strings the same way
+		"in-place union and symmetric difference match their copying counterparts": {
+			sameKindOnly: true,
+			holds: func(a, b, _ string_set.Interface) bool {
+				union, symmetricDifference := a.Copy(), a.Copy()
+				return union.UnionWith(b) == union.Len()-a.Len() && union.IsEqualTo(a.Union(b)) &&
+					symmetricDifference.SymmetricDifferenceWith(b) == a.IntersectionLen(b)+b.Subtract(a).Len() &&
+					symmetricDifference.IsEqualTo(a.SymmetricDifference(b))
+			},
+		},
+		"in-place operations with itself": {
+			holds: func(a, _, _ string_set.Interface) bool {
+				union, intersection, subtract, symmetricDifference := a.Copy(), a.Copy(), a.Copy(), a.Copy()
+				return union.UnionWith(union) == 0 && union.IsEqualTo(a) &&
+					intersection.IntersectWith(intersection) == 0 && intersection.IsEqualTo(a) &&
+					subtract.SubtractWith(subtract) == a.Len() && subtract.IsEmpty() &&
+					symmetricDifference.SymmetricDifferenceWith(symmetricDifference) == a.Len() &&
+					symmetricDifference.IsEmpty()
+			},
+		},
+		// Added takes b's way of comparing strings, so when a's is coarser, several added items may be the same to a
+		"applying a diff turns the old set into the new one": {
+			holds: func(a, b, _ string_set.Interface) bool {
+				d := string_set.Diff(a, b)
+				patched := a.Copy()
+				return patched.Apply(d) <= d.Added.Len()+d.Removed.Len() && patched.IsEqualTo(b) &&
+					d.Unchanged.Len()+d.Removed.Len() == a.Len()
+			},
+		},
+		// b.Union(c) takes b's way of comparing strings, so when the operands differ, grouping changes the answer
+		"union is associative": {
+			sameKindOnly: true,
+			holds: func(a, b, c string_set.Interface) bool {
+				return a.Union(b).Union(c).IsEqualTo(a.Union(b.Union(c)))
+			},
+		},
+		"intersection is associative": {
+			sameKindOnly: true,
+			holds: func(a, b, c string_set.Interface) bool {
+				return a.Intersection(b).Intersection(c).IsEqualTo(a.Intersection(b.Intersection(c)))
+			},
+		},
+	}
+
+	for propertyName, property := range properties {
+		for aName, newA := range kinds {
+			for bName, newB := range kinds {
+				for cName, newC := range kinds {
+					if property.sameKindOnly && (aName != bName || bName != cName) {
+						continue
+					}
+					t.Run(propertyName+"/"+aName+","+bName+","+cName, func(t *testing.T) {
+						err := quick.Check(func(a, b, c []uint8) bool {
+							return property.holds(newA(wordsOf(a)...), newB(wordsOf(b)...), newC(wordsOf(c)...))
+						}, nil)
+						if err != nil {
+							t.Error(err)
+						}
+					})
+				}
+			}
+		}
+	}
+}
+
+func TestSemantics_MixedOperandOrder(t *testing.T) {
+	sensitive := string_set.NewOf("A")
+	insensitive := NewOf("a")
+
+	assert.True(t, sensitive.IsEqualTo(insensitive))
+	assert.True(t, insensitive.IsEqualTo(sensitive))
+
+	// the result takes the type and spelling of the callee
+	assert.Equal(t, []string{"A"}, sensitive.Intersection(insensitive).ToSlice())
+	assert.IsType(t, &string_set.T{}, sensitive.Intersection(insensitive))
+	assert.Equal(t, []string{"a"}, insensitive.Intersection(sensitive).ToSlice())
+	assert.IsType(t, &T{}, insensitive.Intersection(sensitive))
+
+	assert.True(t, string_set.NewOf("a", "A").IsEqualTo(insensitive))
+	assert.Equal(t, 2, string_set.NewOf("a", "A").Union(insensitive).Len())
+	assert.True(t, string_set.NewOf("a", "A").Subtract(insensitive).IsEmpty())
+}
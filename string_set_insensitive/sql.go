@@ -0,0 +1,75 @@
+package string_set_insensitive
+
+import (
+	"database/sql/driver"
+	"github.com/wojnosystems/go-string-set/string_set"
+)
+
+// SQLOptions controls how a case-insensitive set is stored in and read from a database column
+type SQLOptions struct {
+	// Encoding of the column. Defaults to string_set.SQLPostgresArray
+	Encoding string_set.SQLEncoding
+	// Delimiter separates items in string_set.SQLDelimited. Defaults to string_set.DefaultSQLDelimiter
+	Delimiter rune
+	// NormalizedCase stores each value in its normalized form, which is lower-cased unless the set was created with a
+	// different Normalizer. When false, values are stored with the spelling they were added with
+	NormalizedCase bool
+	// AllowDuplicates tolerates a value appearing more than once in the column, ignoring case. When false, scanning
+	// a repeated value fails with a *string_set.DuplicateValueError
+	AllowDuplicates bool
+}
+
+func (o SQLOptions) shared() string_set.SQLOptions {
+	return string_set.SQLOptions{
+		Encoding:        o.Encoding,
+		Delimiter:       o.Delimiter,
+		AllowDuplicates: o.AllowDuplicates,
+	}
+}
+
+// SQL stores Set in a database column encoded as Options selects, such as delimited text or normalized strings.
+// Use it in place of the set, as a struct field or as the argument to Scan and Exec, when the defaults of T.Value
+// and T.Scan do not suit. A nil Set is stored as NULL, and scanned into a new set created by New
+type SQL struct {
+	Set     *T
+	Options SQLOptions
+}
+
+// Value implements driver.Valuer, encoding the set as Options selects, in lexical order. Each value keeps
+// the spelling it was added with unless Options.NormalizedCase is set
+func (s SQL) Value() (driver.Value, error) {
+	if s.Set == nil {
+		return nil, nil
+	}
+	return string_set.EncodeSQL(encoded(s.Set, s.Options.NormalizedCase), s.Options.shared())
+}
+
+// Scan implements sql.Scanner, replacing the contents of the set with the items in a column encoded as Options
+// selects. NULL scans as an empty set. Values that differ only by case are duplicates and are rejected unless
+// Options allows them
+func (s *SQL) Scan(src interface{}) error {
+	decoded := emptyLike(s.Set)
+	if err := string_set.DecodeSQL(src, decoded, s.Options.shared()); err != nil {
+		return err
+	}
+	s.Set = replaced(s.Set, decoded)
+	return nil
+}
+
+// Value implements driver.Valuer, encoding the original spellings of the set as a Postgres array literal, in
+// lexical order. A nil *T is stored as NULL. Use SQL to choose other options
+func (c *T) Value() (driver.Value, error) {
+	return SQL{Set: c}.Value()
+}
+
+// Scan implements sql.Scanner, replacing the contents of the set with the items in a Postgres array literal. A
+// zero-value T may be used, and NULL scans as an empty set. Values that differ only by case are duplicates and are
+// rejected; use SQL to allow them
+func (c *T) Scan(src interface{}) error {
+	return (&SQL{Set: c}).Scan(src)
+}
+
+// Value implements driver.Valuer, encoding the original spellings of the snapshot as a Postgres array literal
+func (f *Frozen) Value() (driver.Value, error) {
+	return f.t.Value()
+}
@@ -0,0 +1,61 @@
+package string_set_insensitive
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/wojnosystems/go-string-set/string_set"
+	"slices"
+	"testing"
+)
+
+func TestCollection_Value(t *testing.T) {
+	cases := map[string]struct {
+		opts     SQLOptions
+		expected string
+	}{
+		"original case": {
+			expected: `{A,"b C"}`,
+		},
+		"normalized": {
+			opts:     SQLOptions{NormalizedCase: true},
+			expected: `{a,"b c"}`,
+		},
+		"delimited": {
+			opts:     SQLOptions{Encoding: string_set.SQLDelimited, Delimiter: ';'},
+			expected: `A;b C`,
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			actual, err := SQL{Set: NewOf("b C", "A"), Options: c.opts}.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, actual)
+		})
+	}
+
+	frozen, err := NewOf("b C", "A").Freeze().Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `{A,"b C"}`, frozen)
+
+	var nilSet *T
+	actual, err := nilSet.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, actual)
+}
+
+func TestCollection_Scan(t *testing.T) {
+	actual := &T{}
+	assert.NoError(t, actual.Scan(`{B,a}`))
+	assert.Equal(t, []string{"B", "a"}, slices.Collect(actual.Sorted()))
+	assert.True(t, actual.Includes("b"))
+
+	assert.Equal(t, &string_set.DuplicateValueError{Value: "A"}, actual.Scan([]byte(`{a,A}`)))
+	assert.Equal(t, 2, actual.Len())
+
+	jsonColumn := &SQL{Set: actual, Options: SQLOptions{Encoding: string_set.SQLJSONArray, AllowDuplicates: true}}
+	assert.NoError(t, jsonColumn.Scan(`["a","A"]`))
+	assert.Equal(t, []string{"a"}, actual.ToSlice())
+
+	assert.NoError(t, actual.Scan(nil))
+	assert.True(t, actual.IsEmpty())
+}
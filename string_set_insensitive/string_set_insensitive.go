@@ -2,16 +2,43 @@ package string_set_insensitive
 
 import (
 	"github.com/wojnosystems/go-string-set/string_set"
-	"strings"
+	"github.com/wojnosystems/go-string-set/string_set_bloom"
+	"iter"
+	"slices"
 )
 
 const (
 	defaultCapacity = 10
 )
 
+// Spelling selects which spelling of a value is remembered when it is added more than once with different casing
+type Spelling uint8
+
+const (
+	// FirstSeen keeps the spelling the value was first added with
+	FirstSeen Spelling = iota
+	// LastSeen replaces the spelling each time the value is added, including by UnionWith and Apply
+	LastSeen
+)
+
+// Options configures a case-insensitive set created with NewWithOptions
+type Options struct {
+	// Capacity is the initial capacity of the set
+	Capacity int
+	// Spelling selects which original spelling is kept. Defaults to FirstSeen
+	Spelling Spelling
+	// Normalizer converts values into the form used to compare them. Defaults to Lower. UnionWith and the other
+	// in-place operations are fastest between sets that share a Normalizer: sets created without one, or sets
+	// created from one another by Copy, Union and the like
+	Normalizer Normalizer
+}
+
+// defaultNormalizer is the Normalizer of every set created without one, including the zero value
+var defaultNormalizer Normalizer = Lower
+
 // Empty is a convenience declaration: it's an empty set you can use to compare
-// to other sets if you want to use IsEqualTo instead of testing with Len
-var Empty = NewWithCapacity(0)
+// to other sets if you want to use IsEqualTo instead of testing with Len. It is frozen, so it cannot be altered
+var Empty = NewWithCapacity(0).Freeze()
 
 // New creates a new String set, with a small default capacity. Case-insensitive
 func New() *T {
@@ -25,26 +52,62 @@ func NewOf(items ...string) *T {
 	return ret
 }
 
+// Collect creates a new string set containing every string produced by seq. Case-insensitive
+func Collect(seq iter.Seq[string]) *T {
+	ret := New()
+	for v := range seq {
+		ret.Add(v)
+	}
+	return ret
+}
+
 // NewWithCapacity creates a new, empty, string set with the provided capacity. Case-insensitive
 func NewWithCapacity(capacity int) *T {
-	return &T{
-		T: string_set.NewWithCapacity(capacity),
-	}
+	return NewWithOptions(Options{
+		Capacity: capacity,
+	})
 }
 
-// convert changes the parameter into the value within the underlying storage
-func convert(v string) string {
-	return strings.ToLower(v)
+// NewWithOptions creates a new, empty, string set configured by opts. Case-insensitive
+func NewWithOptions(opts Options) *T {
+	convert := &defaultNormalizer
+	if opts.Normalizer != nil {
+		convert = &opts.Normalizer
+	}
+	return &T{
+		T:         *string_set.NewWithCapacity(opts.Capacity),
+		originals: make(map[string]string, opts.Capacity),
+		spelling:  opts.Spelling,
+		convert:   convert,
+	}
 }
 
 // T holds the underlying string_set_insensitive type, do not instantiate this yourself,
-// Please use New, NewOf, or NewWithCapacity
+// Please use New, NewOf, NewWithCapacity or NewWithOptions. The zero value is an empty set ready to use, comparing
+// strings as New does
+//
+// The embedded string_set.T holds the normalized values used for lookups. Iteration yields the original spellings.
+// It is embedded by value, so use &c.T where a *string_set.T is needed
 type T struct {
-	*string_set.T
+	string_set.T
+	// originals maps each normalized value to the spelling it was added with
+	originals map[string]string
+	spelling  Spelling
+	// convert points to the Normalizer that changes the parameter into the value within the underlying storage. Sets
+	// created from one another share it, which tells in-place operations that their stored values can be used as
+	// they are. nil means defaultNormalizer
+	convert *Normalizer
 }
 
 func (c *T) Add(v string) {
-	c.T.Add(convert(v))
+	key := c.Normalize(v)
+	if c.originals == nil {
+		c.originals = make(map[string]string, defaultCapacity)
+	}
+	if c.spelling == LastSeen || !c.T.Includes(key) {
+		c.originals[key] = v
+	}
+	c.T.Add(key)
 }
 
 func (c *T) AddMany(v ...string) {
@@ -54,7 +117,7 @@ func (c *T) AddMany(v ...string) {
 }
 
 func (c *T) Remove(v string) {
-	c.T.Remove(convert(v))
+	c.removeKey(c.Normalize(v))
 }
 
 func (c *T) RemoveMany(v ...string) {
@@ -63,57 +126,271 @@ func (c *T) RemoveMany(v ...string) {
 	}
 }
 
-func (c *T) Includes(v string) bool {
-	return c.T.Includes(convert(v))
+// UnionWith adds the items of o that the set does not already contain, as compared by string_set.CompareKey.
+// Returns the number of items added. On a LastSeen set, the items the set already contains take their spelling
+// from o, as Add does
+func (c *T) UnionWith(o string_set.Immutable) (changed int) {
+	other := c.sameKeysAs(o)
+	if other == nil {
+		if _, normalizes := o.(string_set.Normalizer); normalizes && c.spelling == LastSeen {
+			// string_set.UnionWith only adds the items of o that are missing, so respell the others here
+			o.Each(func(v string) {
+				if key := c.Normalize(v); c.T.Includes(key) {
+					c.originals[key] = v
+				}
+			})
+		}
+		return string_set.UnionWith(c, o)
+	}
+	for key, original := range other.originals {
+		if !c.T.Includes(key) {
+			c.addKey(key, original)
+			changed++
+		} else if c.spelling == LastSeen {
+			c.originals[key] = original
+		}
+	}
+	return
 }
 
-func (c *T) IsEqualTo(o string_set.Immutable) (equal bool) {
-	// short-circuit test for speed
-	if c.Len() != o.Len() {
-		return false
+// SubtractWith removes the items that o contains, as compared by string_set.CompareKey. Returns the number of items
+// removed
+func (c *T) SubtractWith(o string_set.Immutable) (changed int) {
+	other := c.sameKeysAs(o)
+	if other == nil {
+		return string_set.SubtractWith(c, o)
 	}
-	equal = true
-	o.EachCancelable(func(v string) string_set.NextAction {
-		if !c.Includes(v) {
-			equal = false
-			return string_set.Break
+	before := c.Len()
+	if other.Len() < c.Len() {
+		for key := range other.originals {
+			c.removeKey(key)
 		}
-		return string_set.Continue
-	})
+	} else {
+		// deleting while ranging over a map is safe, even when other is the callee
+		for key := range c.originals {
+			if other.T.Includes(key) {
+				c.removeKey(key)
+			}
+		}
+	}
+	return before - c.Len()
+}
+
+// IntersectWith removes the items that o does not contain, as compared by string_set.CompareKey. Returns the number of
+// items removed
+func (c *T) IntersectWith(o string_set.Immutable) (changed int) {
+	other := c.sameKeysAs(o)
+	if other == nil {
+		return string_set.IntersectWith(c, o)
+	}
+	for key := range c.originals {
+		if !other.T.Includes(key) {
+			c.removeKey(key)
+			changed++
+		}
+	}
+	return
+}
+
+// SymmetricDifferenceWith removes the items that o contains, and adds the items of o that the set did not contain,
+// as compared by string_set.CompareKey. Returns the number of items added or removed
+func (c *T) SymmetricDifferenceWith(o string_set.Immutable) (changed int) {
+	other := c.sameKeysAs(o)
+	if other == nil {
+		return string_set.SymmetricDifferenceWith(c, o)
+	}
+	// each key of other is seen once, so toggling it cannot undo an earlier change. When other is the callee, every
+	// key is removed and none added, and deleting while ranging over a map is safe
+	for key, original := range other.originals {
+		if c.T.Includes(key) {
+			c.removeKey(key)
+		} else {
+			c.addKey(key, original)
+		}
+		changed++
+	}
+	return
+}
+
+// sameKeysAs returns the case-insensitive set behind o if it shares the callee's Normalizer, so that its normalized
+// keys can be used as they are stored. Returns nil otherwise
+func (c *T) sameKeysAs(o string_set.Immutable) *T {
+	var other *T
+	switch s := o.(type) {
+	case *T:
+		other = s
+	case *Frozen:
+		other = &s.t
+	default:
+		return nil
+	}
+	if c.normalizerID() != other.normalizerID() {
+		return nil
+	}
+	return other
+}
+
+// addKey adds an item by its normalized key, remembering original as its spelling
+func (c *T) addKey(key, original string) {
+	if c.originals == nil {
+		c.originals = make(map[string]string, defaultCapacity)
+	}
+	c.T.Add(key)
+	c.originals[key] = original
+}
+
+// removeKey removes an item by its normalized key
+func (c *T) removeKey(key string) {
+	c.T.Remove(key)
+	delete(c.originals, key)
+}
+
+// Apply removes the items d removed and adds the items d added, as SubtractWith and UnionWith do. Returns the number
+// of items removed or added
+func (c *T) Apply(d string_set.DiffResult) (changed int) {
+	return c.SubtractWith(d.Removed) + c.UnionWith(d.Added)
+}
+
+func (c *T) Includes(v string) bool {
+	return c.T.Includes(c.Normalize(v))
+}
+
+// Normalize returns the form of v that is used to compare it. It implements string_set.Normalizer, so other sets
+// combined with this one know how it compares strings
+func (c *T) Normalize(v string) string {
+	return c.normalizer()(v)
+}
+
+// normalizer returns the Normalizer of the set, which is Lower for a zero-value T
+func (c *T) normalizer() Normalizer {
+	return *c.normalizerID()
+}
+
+// normalizerID returns the pointer to the Normalizer of the set, which is the same for two sets if one was created
+// from the other, or if neither was given a Normalizer
+func (c *T) normalizerID() *Normalizer {
+	if c.convert == nil {
+		return &defaultNormalizer
+	}
+	return c.convert
+}
+
+// Original returns the spelling v was added to the set with, and true. If v is not in the set, returns "" and false
+func (c *T) Original(v string) (original string, ok bool) {
+	original, ok = c.originals[c.Normalize(v)]
 	return
 }
 
+// IsEqualTo returns true if both sets contain the same strings, ignoring case. If o normalizes strings
+// differently, the strings are compared the way string_set.CompareKey describes
+func (c *T) IsEqualTo(o string_set.Immutable) bool {
+	return string_set.IsEqual(c, o)
+}
+
+// Union returns a new case-insensitive set containing all of the items from the callee and the parameter. Where both
+// contain an item, the callee's spelling is kept
 func (c *T) Union(o string_set.Immutable) (out string_set.Interface) {
 	out = c.Copy()
-	o.Each(func(v string) {
-		out.Add(v)
-	})
+	string_set.UnionInto(out, c, o)
 	return
 }
 
+// Subtract returns a new case-insensitive set containing only items from the callee, but without the items in the
+// parameter
 func (c *T) Subtract(o string_set.Immutable) (out string_set.Interface) {
-	out = NewWithCapacity(c.Len())
-	c.Each(func(v string) {
-		if !o.Includes(v) {
-			out.Add(v)
-		}
-	})
+	out = c.empty(c.Len())
+	string_set.SubtractInto(out, c, o)
 	return
 }
 
+// Intersection returns a new case-insensitive set containing only the items of the callee that are also in the
+// parameter, with the callee's spelling
 func (c *T) Intersection(o string_set.Immutable) (out string_set.Interface) {
-	out = NewWithCapacity(c.Len())
-	o.Each(func(v string) {
-		if c.Includes(v) {
-			out.Add(v)
-		}
-	})
+	out = c.empty(c.Len())
+	string_set.IntersectionInto(out, c, o)
+	return
+}
+
+// SymmetricDifference returns a new case-insensitive set containing the items of either the callee or the parameter that are
+// not in both, as compared by string_set.CompareKey
+func (c *T) SymmetricDifference(o string_set.Immutable) (out string_set.Interface) {
+	out = c.empty(c.Len())
+	string_set.SymmetricDifferenceInto(out, c, o)
+	return
+}
+
+// IsSubsetOf returns true if o contains every item of the set, as compared by string_set.CompareKey
+func (c *T) IsSubsetOf(o string_set.Immutable) bool {
+	return string_set.IsSubset(c, o)
+}
+
+// IsProperSubsetOf returns true if o contains every item of the set and has items the set does not, as compared
+// by string_set.CompareKey
+func (c *T) IsProperSubsetOf(o string_set.Immutable) bool {
+	return string_set.IsProperSubset(c, o)
+}
+
+// IsSupersetOf returns true if the set contains every item of o, as compared by string_set.CompareKey
+func (c *T) IsSupersetOf(o string_set.Immutable) bool {
+	return string_set.IsSubset(o, c)
+}
+
+// IsDisjointFrom returns true if the set and o have no items in common, as compared by string_set.CompareKey
+func (c *T) IsDisjointFrom(o string_set.Immutable) bool {
+	return string_set.IsDisjoint(c, o)
+}
+
+// IntersectionLen returns the number of items Intersection would return, without creating the intersection
+func (c *T) IntersectionLen(o string_set.Immutable) int {
+	return string_set.IntersectionLen(c, o)
+}
+
+// ToSlice returns the original spellings of the items in the set, in no particular order
+func (c *T) ToSlice() (out []string) {
+	out = make([]string, 0, len(c.originals))
+	for _, original := range c.originals {
+		out = append(out, original)
+	}
 	return
 }
 
+// Each loops over the original spelling of each item in the set. The order is not guaranteed
+func (c *T) Each(item func(v string)) {
+	for _, original := range c.originals {
+		item(original)
+	}
+}
+
+// EachCancelable is just like Each, but you can stop the iteration by returning
+// string_set.Break instead of string_set.Continue
+func (c *T) EachCancelable(item func(v string) (next string_set.NextAction)) {
+	for _, original := range c.originals {
+		if item(original) == string_set.Break {
+			break
+		}
+	}
+}
+
+// All returns an iterator over the original spelling of each item in the set. The order is not guaranteed
+func (c *T) All() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for _, original := range c.originals {
+			if !yield(original) {
+				return
+			}
+		}
+	}
+}
+
+// Sorted returns an iterator over the original spelling of each item in the set, in lexical order
+func (c *T) Sorted() iter.Seq[string] {
+	return slices.Values(slices.Sorted(c.All()))
+}
+
+// Any returns true if predicate returns true for any item. v is normalized, so compare it to converter(x)
 func (c *T) Any(item func(v string, converter func(in string) string) (didMatch bool)) (anyFound bool) {
 	c.EachCancelable(func(v string) (a string_set.NextAction) {
-		if item(strings.ToLower(v), convert) {
+		if item(c.Normalize(v), c.Normalize) {
 			anyFound = true
 			return string_set.Break
 		}
@@ -122,10 +399,11 @@ func (c *T) Any(item func(v string, converter func(in string) string) (didMatch
 	return
 }
 
+// None returns true if predicate returned false for every item. v is normalized, so compare it to converter(x)
 func (c *T) None(item func(v string, converter func(in string) string) (didMatch bool)) (noneFound bool) {
 	noneFound = true
 	c.EachCancelable(func(v string) (a string_set.NextAction) {
-		if item(strings.ToLower(v), convert) {
+		if item(c.Normalize(v), c.Normalize) {
 			noneFound = false
 			return string_set.Break
 		}
@@ -134,10 +412,27 @@ func (c *T) None(item func(v string, converter func(in string) string) (didMatch
 	return
 }
 
+// ToBloom returns a Bloom filter containing the normalized items of the set. The filter compares strings
+// byte-for-byte, so pass queries through Normalize before calling MayInclude. See string_set.T.ToBloom
+func (c *T) ToBloom(falsePositiveRate float64) *string_set_bloom.Filter {
+	return c.T.ToBloom(falsePositiveRate)
+}
+
+// Copy returns a case-insensitive copy of the set, keeping the original spellings
 func (c *T) Copy() string_set.Interface {
-	outItems := NewWithCapacity(c.Len())
-	c.T.Each(func(v string) {
+	outItems := c.empty(c.Len())
+	c.Each(func(v string) {
 		outItems.Add(v)
 	})
 	return outItems
 }
+
+// empty creates a new, empty set configured like the callee
+func (c *T) empty(capacity int) *T {
+	out := NewWithOptions(Options{
+		Capacity: capacity,
+		Spelling: c.spelling,
+	})
+	out.convert = c.normalizerID()
+	return out
+}
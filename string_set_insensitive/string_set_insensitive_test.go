@@ -1,8 +1,15 @@
 package string_set_insensitive
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
 	"github.com/stretchr/testify/assert"
 	"github.com/wojnosystems/go-string-set/string_set"
+	"gopkg.in/yaml.v3"
+	"slices"
+	"strconv"
 	"testing"
 )
 
@@ -47,7 +54,7 @@ func TestCollection_Remove(t *testing.T) {
 		expected string_set.Immutable
 	}{
 		"empty": {
-			set:      Empty,
+			set:      New(),
 			remove:   []string{"missing"},
 			expected: Empty,
 		},
@@ -83,6 +90,19 @@ func TestCollection_IsEmpty(t *testing.T) {
 	assert.False(t, set.IsEmpty())
 }
 
+func TestCollection_ZeroValue(t *testing.T) {
+	var set T
+	assert.Equal(t, 0, set.Len())
+	assert.True(t, set.IsEmpty())
+	assert.False(t, set.Includes("something"))
+	assert.Empty(t, set.ToSlice())
+	assert.True(t, set.IsEqualTo(Empty))
+
+	set.Add("Something")
+	assert.True(t, set.Includes("SOMETHING"))
+	assert.Equal(t, []string{"Something"}, set.ToSlice())
+}
+
 func TestCollection_IsEqualTo(t *testing.T) {
 	cases := map[string]struct {
 		a        string_set.Immutable
@@ -133,7 +153,7 @@ func TestCollection_Any(t *testing.T) {
 		expected bool
 	}{
 		"empty": {
-			input: Empty,
+			input: New(),
 			test: func(v string, convert func(in string) string) bool {
 				return true
 			},
@@ -167,7 +187,7 @@ func TestCollection_None(t *testing.T) {
 		expected bool
 	}{
 		"empty": {
-			input: Empty,
+			input: New(),
 			test: func(v string, convert func(in string) string) bool {
 				return true
 			},
@@ -332,3 +352,335 @@ func TestCollection_Intersection(t *testing.T) {
 		})
 	}
 }
+
+func TestCollection_InPlaceSetter(t *testing.T) {
+	set := NewOf("a", "B")
+	assert.Equal(t, 1, set.UnionWith(string_set.NewOf("b", "C")))
+	assert.Equal(t, []string{"B", "C", "a"}, slices.Collect(set.Sorted()))
+	assert.Equal(t, 1, set.SubtractWith(string_set.NewOf("A")))
+	assert.Equal(t, 1, set.IntersectWith(NewOf("c", "d")))
+	assert.Equal(t, []string{"C"}, slices.Collect(set.Sorted()))
+	assert.Equal(t, 2, set.SymmetricDifferenceWith(NewOf("c", "D")))
+	assert.Equal(t, []string{"D"}, slices.Collect(set.Sorted()))
+	assert.Equal(t, 1, set.Apply(string_set.Diff(NewOf("d"), NewOf("D", "e"))))
+	assert.Equal(t, []string{"D", "e"}, slices.Collect(set.Sorted()))
+}
+
+func TestCollection_InPlaceSetterOperands(t *testing.T) {
+	cases := map[string]struct {
+		b          string_set.Immutable
+		sameKeysAs bool
+	}{
+		"same normalizer": {
+			b:          NewOf("b", "C"),
+			sameKeysAs: true,
+		},
+		"frozen": {
+			b:          NewOf("b", "C").Freeze(),
+			sameKeysAs: true,
+		},
+		"other normalizer": {
+			b: NewWithOptions(Options{Normalizer: Chain(Lower)}).Union(string_set.NewOf("b", "C")),
+		},
+		"case-sensitive": {
+			b: string_set.NewOf("b", "C"),
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			assert.Equal(t, c.sameKeysAs, New().sameKeysAs(c.b) != nil)
+
+			union := NewOf("a", "B")
+			assert.Equal(t, 1, union.UnionWith(c.b))
+			assert.Equal(t, []string{"B", "C", "a"}, slices.Collect(union.Sorted()))
+			subtract := NewOf("a", "B")
+			assert.Equal(t, 1, subtract.SubtractWith(c.b))
+			assert.Equal(t, []string{"a"}, slices.Collect(subtract.Sorted()))
+			intersection := NewOf("a", "B")
+			assert.Equal(t, 1, intersection.IntersectWith(c.b))
+			assert.Equal(t, []string{"B"}, slices.Collect(intersection.Sorted()))
+			symmetricDifference := NewOf("a", "B")
+			assert.Equal(t, 2, symmetricDifference.SymmetricDifferenceWith(c.b))
+			assert.Equal(t, []string{"C", "a"}, slices.Collect(symmetricDifference.Sorted()))
+			assert.True(t, symmetricDifference.Includes("c"))
+		})
+	}
+}
+
+func TestCollection_UnionWithSpelling(t *testing.T) {
+	cases := map[string]struct {
+		spelling Spelling
+		o        string_set.Immutable
+		expected []string
+	}{
+		"first seen": {
+			o:        NewOf("FOO", "Bar"),
+			expected: []string{"Bar", "Foo"},
+		},
+		"last seen": {
+			spelling: LastSeen,
+			o:        NewOf("FOO", "Bar"),
+			expected: []string{"Bar", "FOO"},
+		},
+		"last seen from frozen": {
+			spelling: LastSeen,
+			o:        NewOf("FOO", "Bar").Freeze(),
+			expected: []string{"Bar", "FOO"},
+		},
+		"last seen from other normalizer": {
+			spelling: LastSeen,
+			o:        NewWithOptions(Options{Normalizer: Fold}).Union(string_set.NewOf("FOO", "Bar")),
+			expected: []string{"Bar", "FOO"},
+		},
+		"last seen from case-sensitive": {
+			spelling: LastSeen,
+			o:        string_set.NewOf("FOO", "Bar"),
+			expected: []string{"Bar", "FOO"},
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			set := NewWithOptions(Options{Spelling: c.spelling})
+			set.Add("Foo")
+			assert.Equal(t, 1, set.UnionWith(c.o))
+			assert.Equal(t, c.expected, slices.Collect(set.Sorted()))
+		})
+	}
+}
+
+func TestCollection_SameKeysAs(t *testing.T) {
+	var zero T
+	assert.NotNil(t, zero.sameKeysAs(New()))
+	assert.NotNil(t, New().sameKeysAs(NewOf("a").Freeze()))
+
+	folded := NewWithOptions(Options{Normalizer: Fold})
+	assert.Nil(t, folded.sameKeysAs(New()))
+	assert.Nil(t, folded.sameKeysAs(NewWithOptions(Options{Normalizer: Fold})))
+	assert.NotNil(t, folded.sameKeysAs(folded.Copy()))
+	assert.NotNil(t, folded.sameKeysAs(folded.Union(NewOf("a"))))
+	assert.NotNil(t, folded.sameKeysAs(folded.Freeze()))
+}
+
+func TestCollection_InPlaceSetterWithItself(t *testing.T) {
+	set := NewOf("a", "B")
+	assert.Equal(t, 0, set.UnionWith(set))
+	assert.Equal(t, 0, set.IntersectWith(set))
+	assert.Equal(t, 2, set.Len())
+	assert.Equal(t, 2, set.SymmetricDifferenceWith(set))
+	assert.True(t, set.IsEmpty())
+
+	var zero T
+	assert.Equal(t, 1, zero.UnionWith(NewOf("A")))
+	assert.Equal(t, 1, zero.SubtractWith(&zero))
+	assert.True(t, zero.IsEmpty())
+}
+
+func TestCollection_Freeze(t *testing.T) {
+	set := NewOf("a", "B")
+	frozen := set.Freeze()
+	set.Add("c")
+	set.Remove("A")
+
+	assert.True(t, frozen.Includes("A"))
+	assert.True(t, frozen.Includes("b"))
+	assert.False(t, frozen.Includes("c"))
+	_, isMutable := interface{}(frozen).(string_set.Mutable)
+	assert.False(t, isMutable)
+	_, isMutable = interface{}(Empty).(string_set.Mutable)
+	assert.False(t, isMutable)
+}
+
+func TestCollection_Sorted(t *testing.T) {
+	set := Collect(slices.Values([]string{"b", "C", "A", "c"}))
+	assert.Equal(t, []string{"A", "C", "b"}, slices.Collect(set.Sorted()))
+	assert.Equal(t, []string{"A", "C", "b"}, slices.Collect(set.Freeze().Sorted()))
+	assert.Equal(t, 3, len(slices.Collect(set.All())))
+}
+
+func TestCollection_Original(t *testing.T) {
+	cases := map[string]struct {
+		set      *T
+		input    []string
+		lookup   string
+		expected string
+		found    bool
+	}{
+		"missing": {
+			set:    New(),
+			input:  []string{"McDonald"},
+			lookup: "Smith",
+		},
+		"first seen": {
+			set:      New(),
+			input:    []string{"McDonald", "MCDONALD"},
+			lookup:   "mcdonald",
+			expected: "McDonald",
+			found:    true,
+		},
+		"last seen": {
+			set:      NewWithOptions(Options{Spelling: LastSeen}),
+			input:    []string{"McDonald", "MCDONALD"},
+			lookup:   "mcdonald",
+			expected: "MCDONALD",
+			found:    true,
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			c.set.AddMany(c.input...)
+			actual, found := c.set.Original(c.lookup)
+			assert.Equal(t, c.expected, actual)
+			assert.Equal(t, c.found, found)
+			if found {
+				assert.Equal(t, []string{c.expected}, c.set.ToSlice())
+			}
+		})
+	}
+}
+
+func TestCollection_OriginalSurvivesOperations(t *testing.T) {
+	set := NewOf("McDonald", "Smith")
+	set.Remove("SMITH")
+	_, found := set.Original("smith")
+	assert.False(t, found)
+
+	union := set.Union(NewOf("MCDONALD", "Jones")).(*T)
+	assert.ElementsMatch(t, []string{"McDonald", "Jones"}, union.ToSlice())
+
+	copied := NewWithOptions(Options{Spelling: LastSeen})
+	copied.Add("a")
+	copied = copied.Copy().(*T)
+	copied.Add("A")
+	original, _ := copied.Original("a")
+	assert.Equal(t, "A", original)
+
+	var each []string
+	set.Each(func(v string) {
+		each = append(each, v)
+	})
+	assert.Equal(t, []string{"McDonald"}, each)
+	original, _ = set.Freeze().Original("MCDONALD")
+	assert.Equal(t, "McDonald", original)
+}
+
+func TestCollection_OriginalSurvivesEncoding(t *testing.T) {
+	cases := map[string]func(in, out *T) error{
+		"JSON": func(in, out *T) error {
+			data, err := json.Marshal(in)
+			if err != nil {
+				return err
+			}
+			return json.Unmarshal(data, out)
+		},
+		"gob": func(in, out *T) error {
+			buffer := bytes.Buffer{}
+			if err := gob.NewEncoder(&buffer).Encode(in); err != nil {
+				return err
+			}
+			return gob.NewDecoder(&buffer).Decode(out)
+		},
+		"XML": func(in, out *T) error {
+			data, err := xml.Marshal(in)
+			if err != nil {
+				return err
+			}
+			return xml.Unmarshal(data, out)
+		},
+		"YAML": func(in, out *T) error {
+			data, err := yaml.Marshal(in)
+			if err != nil {
+				return err
+			}
+			return yaml.Unmarshal(data, out)
+		},
+		"SQL": func(in, out *T) error {
+			value, err := in.Value()
+			if err != nil {
+				return err
+			}
+			return out.Scan(value)
+		},
+		"lines": func(in, out *T) error {
+			buffer := bytes.Buffer{}
+			if _, err := in.WriteTo(&buffer); err != nil {
+				return err
+			}
+			_, err := out.ReadFrom(&buffer)
+			return err
+		},
+	}
+
+	for caseName, roundTrip := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			actual := &T{}
+			assert.NoError(t, roundTrip(NewOf("McDonald", "smith"), actual))
+			original, ok := actual.Original("MCDONALD")
+			assert.True(t, ok)
+			assert.Equal(t, "McDonald", original)
+			assert.Equal(t, []string{"McDonald", "smith"}, slices.Collect(actual.Sorted()))
+		})
+	}
+}
+
+// foldedSets are the sets the fold benchmarks combine, 200 sets of 100 items each, overlapping by half
+func foldedSets() (sets []string_set.Immutable) {
+	for i := 0; i < 200; i++ {
+		set := New()
+		for j := 0; j < 100; j++ {
+			set.Add("Item" + strconv.Itoa(i*50+j))
+		}
+		sets = append(sets, set)
+	}
+	return
+}
+
+func BenchmarkFold_Union(b *testing.B) {
+	sets := foldedSets()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var out string_set.Interface = New()
+		for _, set := range sets {
+			out = out.Union(set)
+		}
+	}
+}
+
+func BenchmarkFold_UnionWith(b *testing.B) {
+	sets := foldedSets()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out := New()
+		for _, set := range sets {
+			out.UnionWith(set)
+		}
+	}
+}
+
+func BenchmarkFold_Intersection(b *testing.B) {
+	sets := foldedSets()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out := sets[0].Copy()
+		for _, set := range sets[1:] {
+			out = out.Intersection(set)
+		}
+	}
+}
+
+func BenchmarkFold_IntersectWith(b *testing.B) {
+	sets := foldedSets()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out := sets[0].Copy()
+		for _, set := range sets[1:] {
+			out.IntersectWith(set)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package string_set_insensitive
+
+import (
+	"encoding/xml"
+	"github.com/wojnosystems/go-string-set/string_set"
+)
+
+// XMLOptions controls how a case-insensitive set is encoded to and decoded from XML
+type XMLOptions struct {
+	// ElementName is the name of the element each item is encoded in, and the only element decoded as an item.
+	// Defaults to string_set.DefaultXMLElementName
+	ElementName string
+	// NormalizedCase emits each value in its normalized form, which is lower-cased unless the set was created with a
+	// different Normalizer. When false, values are emitted with the spelling they were added with
+	NormalizedCase bool
+	// AllowDuplicates tolerates a value appearing more than once, ignoring case. When false, decoding a repeated
+	// value fails with a *string_set.DuplicateValueError
+	AllowDuplicates bool
+}
+
+func (o XMLOptions) shared() string_set.XMLOptions {
+	return string_set.XMLOptions{
+		ElementName:     o.ElementName,
+		AllowDuplicates: o.AllowDuplicates,
+	}
+}
+
+// XML encodes and decodes Set as XML with Options, such as a different name for the item elements. Use it in place
+// of the set, as a struct field or as the argument to xml.Marshal and xml.Unmarshal, when the defaults of
+// T.MarshalXML and T.UnmarshalXML do not suit. A nil Set is encoded as an empty element, and decoded into a new set
+// created by New
+type XML struct {
+	Set     *T
+	Options XMLOptions
+}
+
+// MarshalXML encodes the set as one element per item, in lexical order, inside start. Each value keeps the
+// spelling it was added with unless Options.NormalizedCase is set
+func (x XML) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return string_set.EncodeXML(e, start, encoded(x.Set, x.Options.NormalizedCase), x.Options.shared())
+}
+
+// UnmarshalXML replaces the contents of the set with the text of each item element inside start. Values that
+// differ only by case are duplicates and are rejected unless Options allows them
+func (x *XML) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	decoded := emptyLike(x.Set)
+	if err := string_set.DecodeXML(d, start, decoded, x.Options.shared()); err != nil {
+		return err
+	}
+	x.Set = replaced(x.Set, decoded)
+	return nil
+}
+
+// MarshalXML encodes the original spellings of the set as one element per item, named
+// string_set.DefaultXMLElementName, in lexical order, inside start. Use XML to choose other options
+func (c *T) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return XML{Set: c}.MarshalXML(e, start)
+}
+
+// UnmarshalXML replaces the contents of the set with the text of each element named
+// string_set.DefaultXMLElementName inside start. A zero-value T may be used. Values that differ only by case are
+// duplicates and are rejected; use XML to allow them
+func (c *T) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return (&XML{Set: c}).UnmarshalXML(d, start)
+}
+
+// MarshalXML encodes the original spellings of the snapshot as one element per item, in lexical order
+func (f *Frozen) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return f.t.MarshalXML(e, start)
+}
@@ -0,0 +1,58 @@
+package string_set_insensitive
+
+import (
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"github.com/wojnosystems/go-string-set/string_set"
+	"slices"
+	"testing"
+)
+
+type xmlDocument struct {
+	XMLName xml.Name `xml:"doc"`
+	Tags    *T       `xml:"tags"`
+}
+
+func TestCollection_MarshalXML(t *testing.T) {
+	cases := map[string]struct {
+		opts     XMLOptions
+		expected string
+	}{
+		"original case": {
+			expected: `<doc><tags><item>A</item><item>b</item></tags></doc>`,
+		},
+		"normalized": {
+			opts:     XMLOptions{NormalizedCase: true, ElementName: "tag"},
+			expected: `<doc><tags><tag>a</tag><tag>b</tag></tags></doc>`,
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			actual, err := xml.Marshal(struct {
+				XMLName xml.Name `xml:"doc"`
+				Tags    XML      `xml:"tags"`
+			}{Tags: XML{Set: NewOf("b", "A"), Options: c.opts}})
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, string(actual))
+		})
+	}
+}
+
+func TestCollection_UnmarshalXML(t *testing.T) {
+	var actual xmlDocument
+	assert.NoError(t, xml.Unmarshal([]byte(`<doc><tags><item>B</item><item>a</item></tags></doc>`), &actual))
+	assert.Equal(t, []string{"B", "a"}, slices.Collect(actual.Tags.Sorted()))
+	assert.True(t, actual.Tags.Includes("b"))
+
+	err := xml.Unmarshal([]byte(`<doc><tags><item>a</item><item>A</item></tags></doc>`), &actual)
+	assert.Equal(t, &string_set.DuplicateValueError{Value: "A"}, err)
+
+	var withName struct {
+		XMLName xml.Name `xml:"doc"`
+		Tags    XML      `xml:"tags"`
+	}
+	withName.Tags.Options.ElementName = "tag"
+	assert.NoError(t, xml.Unmarshal([]byte(`<doc><tags><tag>B</tag><item>x</item></tags></doc>`), &withName))
+	assert.Equal(t, []string{"B"}, slices.Collect(withName.Tags.Set.Sorted()))
+}
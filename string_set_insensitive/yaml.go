@@ -0,0 +1,61 @@
+package string_set_insensitive
+
+import (
+	"github.com/wojnosystems/go-string-set/string_set"
+)
+
+// YAMLOptions controls how a case-insensitive set is encoded to and decoded from YAML
+type YAMLOptions struct {
+	// NormalizedCase emits each value in its normalized form, which is lower-cased unless the set was created with a
+	// different Normalizer. When false, values are emitted with the spelling they were added with
+	NormalizedCase bool
+	// AllowDuplicates tolerates a value appearing more than once in the YAML sequence, ignoring case. When false,
+	// decoding a repeated value fails with a *string_set.DuplicateValueError
+	AllowDuplicates bool
+}
+
+// YAML encodes and decodes Set as a YAML sequence with Options, such as normalizing the strings. Use it in
+// place of the set, as a struct field or as the argument to Unmarshal, when the defaults of T.MarshalYAML and
+// T.UnmarshalYAML do not suit. A nil Set is encoded as an empty sequence, and decoded into a new set created by New.
+// It works with both gopkg.in/yaml.v2 and gopkg.in/yaml.v3
+type YAML struct {
+	Set     *T
+	Options YAMLOptions
+}
+
+// MarshalYAML encodes the set as a YAML sequence of strings in lexical order. Each value keeps the spelling it
+// was added with unless Options.NormalizedCase is set
+func (y YAML) MarshalYAML() (interface{}, error) {
+	return string_set.SortedSlice(encoded(y.Set, y.Options.NormalizedCase)), nil
+}
+
+// UnmarshalYAML replaces the contents of the set with the strings in a YAML sequence. Values that differ only by
+// case are duplicates and are rejected unless Options allows them
+func (y *YAML) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	decoded := emptyLike(y.Set)
+	err := string_set.DecodeYAML(unmarshal, decoded, string_set.YAMLOptions{
+		AllowDuplicates: y.Options.AllowDuplicates,
+	})
+	if err != nil {
+		return err
+	}
+	y.Set = replaced(y.Set, decoded)
+	return nil
+}
+
+// MarshalYAML encodes the original spellings of the set as a YAML sequence in lexical order. It works with both
+// gopkg.in/yaml.v2 and gopkg.in/yaml.v3
+func (c *T) MarshalYAML() (interface{}, error) {
+	return YAML{Set: c}.MarshalYAML()
+}
+
+// UnmarshalYAML replaces the contents of the set with the strings in a YAML sequence. A zero-value T may be used.
+// Values that differ only by case are duplicates and are rejected; use YAML to allow them
+func (c *T) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return (&YAML{Set: c}).UnmarshalYAML(unmarshal)
+}
+
+// MarshalYAML encodes the original spellings of the snapshot as a YAML sequence in lexical order
+func (f *Frozen) MarshalYAML() (interface{}, error) {
+	return f.t.MarshalYAML()
+}
@@ -0,0 +1,45 @@
+package string_set_insensitive
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/wojnosystems/go-string-set/string_set"
+	"gopkg.in/yaml.v3"
+	"slices"
+	"testing"
+)
+
+type yamlDocument struct {
+	Tags *T `yaml:"tags"`
+}
+
+func TestCollection_MarshalYAML(t *testing.T) {
+	set := NewOf("b", "A")
+	actual, err := yaml.Marshal(yamlDocument{Tags: set})
+	assert.NoError(t, err)
+	assert.Equal(t, "tags:\n    - A\n    - b\n", string(actual))
+
+	actual, err = yaml.Marshal(map[string]*Frozen{"tags": set.Freeze()})
+	assert.NoError(t, err)
+	assert.Equal(t, "tags:\n    - A\n    - b\n", string(actual))
+
+	actual, err = yaml.Marshal(map[string]YAML{"tags": {Set: set, Options: YAMLOptions{NormalizedCase: true}}})
+	assert.NoError(t, err)
+	assert.Equal(t, "tags:\n    - a\n    - b\n", string(actual))
+}
+
+func TestCollection_UnmarshalYAML(t *testing.T) {
+	var actual yamlDocument
+	assert.NoError(t, yaml.Unmarshal([]byte("tags: [B, a]"), &actual))
+	assert.Equal(t, []string{"B", "a"}, slices.Collect(actual.Tags.Sorted()))
+	assert.True(t, actual.Tags.Includes("b"))
+
+	err := yaml.Unmarshal([]byte("tags: [a, A]"), &actual)
+	assert.Equal(t, &string_set.DuplicateValueError{Value: "A"}, err)
+
+	var allowed struct {
+		Tags YAML `yaml:"tags"`
+	}
+	allowed.Tags.Options.AllowDuplicates = true
+	assert.NoError(t, yaml.Unmarshal([]byte("tags: [a, A]"), &allowed))
+	assert.Equal(t, 1, allowed.Tags.Set.Len())
+}
@@ -0,0 +1,321 @@
+package string_set_observable
+
+import (
+	"github.com/wojnosystems/go-string-set/string_set"
+	"iter"
+	"slices"
+)
+
+const (
+	defaultCapacity = 10
+)
+
+// New creates a new String set that reports changes to its listeners, with a small default capacity
+func New() *T {
+	return NewWithCapacity(defaultCapacity)
+}
+
+// NewOf is a convenience method to create an observable string set containing the items you specify. No events are
+// sent for them, as the set has no listeners yet
+func NewOf(items ...string) *T {
+	ret := NewWithCapacity(len(items))
+	ret.AddMany(items...)
+	return ret
+}
+
+// NewWithCapacity creates a new, empty, observable string set with the provided capacity
+func NewWithCapacity(capacity int) *T {
+	return &T{
+		set: string_set.NewWithCapacity(capacity),
+	}
+}
+
+// Event describes the items whose membership was changed by one call that modified the set. Add and Remove send
+// an Event with one item, while AddMany, RemoveMany, Apply and the in-place set algebra, such as UnionWith, send one
+// Event with every item they changed, in the order they changed them. Items that were already in the set when
+// added, or not in it when removed, are left out, and calls that change nothing send no Event
+type Event struct {
+	Added   []string
+	Removed []string
+}
+
+// Listener is called with each Event after the change it describes has been made
+type Listener func(e Event)
+
+// PerItem returns a Listener that calls added for each item added and removed for each item removed, for listeners
+// that do not care about batches. Either may be nil
+func PerItem(added, removed func(v string)) Listener {
+	return func(e Event) {
+		if added != nil {
+			for _, v := range e.Added {
+				added(v)
+			}
+		}
+		if removed != nil {
+			for _, v := range e.Removed {
+				removed(v)
+			}
+		}
+	}
+}
+
+// T holds the underlying string_set_observable type, do not instantiate this yourself,
+// Please use New, NewOf, or NewWithCapacity
+//
+// T is a string_set.T that sends an Event to its listeners whenever an item is added or removed. Listeners are called
+// in the order they subscribed, on the goroutine that changed the set, before the call that changed it returns.
+// Like string_set.T, it is not safe for concurrent use, and listeners must not modify the set they are listening to
+type T struct {
+	set       *string_set.T
+	listeners []*Listener
+	// pending collects the changes of a batch, while one is being made
+	pending *Event
+}
+
+// Subscribe calls l with each later Event. Call the returned func to stop
+func (c *T) Subscribe(l Listener) (unsubscribe func()) {
+	subscription := &l
+	c.listeners = append(c.listeners, subscription)
+	return func() {
+		c.listeners = slices.DeleteFunc(c.listeners, func(s *Listener) bool {
+			return s == subscription
+		})
+	}
+}
+
+// SubscribeChan sends each later Event to ch. The call that changed the set blocks until the Event is sent, so ch
+// should be buffered, or received from by another goroutine. Call the returned func to stop; ch is not closed
+func (c *T) SubscribeChan(ch chan<- Event) (unsubscribe func()) {
+	return c.Subscribe(func(e Event) {
+		ch <- e
+	})
+}
+
+func (c *T) Add(v string) {
+	if c.set.Includes(v) {
+		return
+	}
+	c.set.Add(v)
+	c.record(Event{Added: []string{v}})
+}
+
+func (c *T) AddMany(v ...string) {
+	c.batch(func() {
+		for _, s := range v {
+			c.Add(s)
+		}
+	})
+}
+
+func (c *T) Remove(v string) {
+	if !c.set.Includes(v) {
+		return
+	}
+	c.set.Remove(v)
+	c.record(Event{Removed: []string{v}})
+}
+
+func (c *T) RemoveMany(v ...string) {
+	c.batch(func() {
+		for _, s := range v {
+			c.Remove(s)
+		}
+	})
+}
+
+// UnionWith adds the items of o that the set does not already contain, as compared by string_set.CompareKey.
+// Returns the number of items added, which are sent as one Event
+func (c *T) UnionWith(o string_set.Immutable) (changed int) {
+	c.batch(func() {
+		changed = string_set.UnionWith(c, unwrap(o))
+	})
+	return
+}
+
+// SubtractWith removes the items that o contains, as compared by string_set.CompareKey. Returns the number of items
+// removed, which are sent as one Event
+func (c *T) SubtractWith(o string_set.Immutable) (changed int) {
+	c.batch(func() {
+		changed = string_set.SubtractWith(c, unwrap(o))
+	})
+	return
+}
+
+// IntersectWith removes the items that o does not contain, as compared by string_set.CompareKey. Returns the number
+// of items removed, which are sent as one Event
+func (c *T) IntersectWith(o string_set.Immutable) (changed int) {
+	c.batch(func() {
+		changed = string_set.IntersectWith(c, unwrap(o))
+	})
+	return
+}
+
+// SymmetricDifferenceWith removes the items that o contains, and adds the items of o that the set did not contain,
+// as compared by string_set.CompareKey. Returns the number of items added or removed, which are sent as one Event
+func (c *T) SymmetricDifferenceWith(o string_set.Immutable) (changed int) {
+	c.batch(func() {
+		changed = string_set.SymmetricDifferenceWith(c, unwrap(o))
+	})
+	return
+}
+
+// Apply removes the items d removed and adds the items d added, as SubtractWith and UnionWith do. Returns the number
+// of items removed or added, which are sent as one Event
+func (c *T) Apply(d string_set.DiffResult) (changed int) {
+	c.batch(func() {
+		changed = c.SubtractWith(d.Removed) + c.UnionWith(d.Added)
+	})
+	return
+}
+
+func (c *T) Includes(v string) bool {
+	return c.set.Includes(v)
+}
+
+func (c *T) IsEmpty() bool {
+	return c.set.IsEmpty()
+}
+
+func (c *T) Len() int {
+	return c.set.Len()
+}
+
+func (c *T) IsEqualTo(o string_set.Immutable) bool {
+	return c.set.IsEqualTo(unwrap(o))
+}
+
+// Union returns a new observable set, with no listeners, containing all of the items from the callee and the
+// parameter
+func (c *T) Union(o string_set.Immutable) string_set.Interface {
+	return wrap(c.set.Union(unwrap(o)))
+}
+
+// Subtract returns a new observable set, with no listeners, containing only items from the callee, but without the
+// items in the parameter
+func (c *T) Subtract(o string_set.Immutable) string_set.Interface {
+	return wrap(c.set.Subtract(unwrap(o)))
+}
+
+// Intersection returns a new observable set, with no listeners, containing only items common to both the callee and
+// parameter
+func (c *T) Intersection(o string_set.Immutable) string_set.Interface {
+	return wrap(c.set.Intersection(unwrap(o)))
+}
+
+// SymmetricDifference returns a new observable set, with no listeners, containing the items of either the callee or
+// the parameter that are not in both
+func (c *T) SymmetricDifference(o string_set.Immutable) string_set.Interface {
+	return wrap(c.set.SymmetricDifference(unwrap(o)))
+}
+
+func (c *T) IsSubsetOf(o string_set.Immutable) bool {
+	return c.set.IsSubsetOf(unwrap(o))
+}
+
+func (c *T) IsProperSubsetOf(o string_set.Immutable) bool {
+	return c.set.IsProperSubsetOf(unwrap(o))
+}
+
+func (c *T) IsSupersetOf(o string_set.Immutable) bool {
+	return c.set.IsSupersetOf(unwrap(o))
+}
+
+func (c *T) IsDisjointFrom(o string_set.Immutable) bool {
+	return c.set.IsDisjointFrom(unwrap(o))
+}
+
+func (c *T) IntersectionLen(o string_set.Immutable) int {
+	return c.set.IntersectionLen(unwrap(o))
+}
+
+func (c *T) ToSlice() []string {
+	return c.set.ToSlice()
+}
+
+func (c *T) Each(item func(v string)) {
+	c.set.Each(item)
+}
+
+func (c *T) EachCancelable(item func(v string) (next string_set.NextAction)) {
+	c.set.EachCancelable(item)
+}
+
+func (c *T) All() iter.Seq[string] {
+	return c.set.All()
+}
+
+// Sorted returns an iterator over the items of the set in lexical order
+func (c *T) Sorted() iter.Seq[string] {
+	return c.set.Sorted()
+}
+
+// Any returns true if predicate returns true for any item. See string_set.T.Any
+func (c *T) Any(item func(v string) (didMatch bool)) bool {
+	return c.set.Any(item)
+}
+
+// None returns true if predicate returned false for every item in the set. See string_set.T.None
+func (c *T) None(item func(v string) (didMatch bool)) bool {
+	return c.set.None(item)
+}
+
+// Copy returns a new observable set, with no listeners, containing the items of the set
+func (c *T) Copy() string_set.Interface {
+	return wrap(c.set.Copy())
+}
+
+// Freeze returns a read-only snapshot of the set
+func (c *T) Freeze() *string_set.Frozen {
+	return c.set.Freeze()
+}
+
+// batch runs change, which may make many changes, and sends them as one Event. Batches within a batch are part of
+// the outer one
+func (c *T) batch(change func()) {
+	if c.pending != nil {
+		change()
+		return
+	}
+	c.pending = &Event{}
+	defer func() {
+		e := *c.pending
+		c.pending = nil
+		if len(e.Added) > 0 || len(e.Removed) > 0 {
+			c.send(e)
+		}
+	}()
+	change()
+}
+
+// record adds the changes in e to the batch being made, or sends e if there is none
+func (c *T) record(e Event) {
+	if c.pending == nil {
+		c.send(e)
+		return
+	}
+	c.pending.Added = append(c.pending.Added, e.Added...)
+	c.pending.Removed = append(c.pending.Removed, e.Removed...)
+}
+
+// send calls each listener with e
+func (c *T) send(e Event) {
+	// listeners may unsubscribe while being called
+	for _, l := range slices.Clone(c.listeners) {
+		(*l)(e)
+	}
+}
+
+// unwrap returns the set o observes if o is a *T, so operations on it use string_set.T's faster paths
+func unwrap(o string_set.Immutable) string_set.Immutable {
+	if other, ok := o.(*T); ok {
+		return other.set
+	}
+	return o
+}
+
+// wrap makes the result of an operation on the underlying set observable
+func wrap(s string_set.Interface) *T {
+	return &T{
+		set: s.(*string_set.T),
+	}
+}
@@ -0,0 +1,217 @@
+package string_set_observable
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/wojnosystems/go-string-set/string_set"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// recorder collects the events sent to a set
+type recorder struct {
+	events []Event
+}
+
+func (r *recorder) listen(e Event) {
+	r.events = append(r.events, e)
+}
+
+func TestCollection_Events(t *testing.T) {
+	cases := map[string]struct {
+		initial  []string
+		change   func(set *T)
+		expected []Event
+		result   []string
+	}{
+		"add": {
+			change: func(set *T) {
+				set.Add("a")
+				set.Add("b")
+			},
+			expected: []Event{{Added: []string{"a"}}, {Added: []string{"b"}}},
+			result:   []string{"a", "b"},
+		},
+		"add existing": {
+			initial: []string{"a"},
+			change: func(set *T) {
+				set.Add("a")
+			},
+			result: []string{"a"},
+		},
+		"remove": {
+			initial: []string{"a", "b"},
+			change: func(set *T) {
+				set.Remove("a")
+				set.Remove("x")
+			},
+			expected: []Event{{Removed: []string{"a"}}},
+			result:   []string{"b"},
+		},
+		"add many is one event without duplicates": {
+			initial: []string{"a"},
+			change: func(set *T) {
+				set.AddMany("b", "a", "c", "b")
+			},
+			expected: []Event{{Added: []string{"b", "c"}}},
+			result:   []string{"a", "b", "c"},
+		},
+		"remove many": {
+			initial: []string{"a", "b", "c"},
+			change: func(set *T) {
+				set.RemoveMany("c", "x", "a", "c")
+				set.RemoveMany("x")
+			},
+			expected: []Event{{Removed: []string{"c", "a"}}},
+			result:   []string{"b"},
+		},
+		"union with": {
+			initial: []string{"a"},
+			change: func(set *T) {
+				assert.Equal(t, 1, set.UnionWith(string_set.NewOf("a", "b")))
+				assert.Equal(t, 0, set.UnionWith(string_set.NewOf("a")))
+			},
+			expected: []Event{{Added: []string{"b"}}},
+			result:   []string{"a", "b"},
+		},
+		"union with a normalized set": {
+			initial: []string{"A"},
+			change: func(set *T) {
+				assert.Equal(t, 1, set.UnionWith(string_set.NewNormalizedOf(strings.ToLower, "a", "b")))
+			},
+			expected: []Event{{Added: []string{"b"}}},
+			result:   []string{"A", "b"},
+		},
+		"subtract with": {
+			initial: []string{"a", "b", "c"},
+			change: func(set *T) {
+				assert.Equal(t, 2, set.SubtractWith(NewOf("a", "c", "d")))
+			},
+			expected: []Event{{Removed: []string{"a", "c"}}},
+			result:   []string{"b"},
+		},
+		"intersect with": {
+			initial: []string{"a", "b", "c"},
+			change: func(set *T) {
+				assert.Equal(t, 1, set.IntersectWith(string_set.NewOf("a", "c", "d")))
+			},
+			expected: []Event{{Removed: []string{"b"}}},
+			result:   []string{"a", "c"},
+		},
+		"symmetric difference with": {
+			initial: []string{"a", "b"},
+			change: func(set *T) {
+				assert.Equal(t, 2, set.SymmetricDifferenceWith(string_set.NewOf("b", "c")))
+			},
+			expected: []Event{{Added: []string{"c"}, Removed: []string{"b"}}},
+			result:   []string{"a", "c"},
+		},
+		"apply": {
+			initial: []string{"a", "b"},
+			change: func(set *T) {
+				assert.Equal(t, 2, set.Apply(string_set.Diff(string_set.NewOf("a", "b"), string_set.NewOf("b", "c"))))
+			},
+			expected: []Event{{Added: []string{"c"}, Removed: []string{"a"}}},
+			result:   []string{"b", "c"},
+		},
+		"with itself": {
+			initial: []string{"a", "b"},
+			change: func(set *T) {
+				assert.Equal(t, 0, set.UnionWith(set))
+				assert.Equal(t, 0, set.IntersectWith(set))
+				assert.Equal(t, 2, set.SubtractWith(set))
+			},
+			expected: []Event{{Removed: []string{"a", "b"}}},
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			set := NewOf(c.initial...)
+			events := &recorder{}
+			set.Subscribe(events.listen)
+			c.change(set)
+			assert.Equal(t, len(c.expected), len(events.events))
+			for i := range min(len(c.expected), len(events.events)) {
+				assert.ElementsMatch(t, c.expected[i].Added, events.events[i].Added)
+				assert.ElementsMatch(t, c.expected[i].Removed, events.events[i].Removed)
+			}
+			assert.ElementsMatch(t, c.result, set.ToSlice())
+		})
+	}
+}
+
+func TestCollection_Subscribe(t *testing.T) {
+	set := New()
+	first, second := &recorder{}, &recorder{}
+	unsubscribeFirst := set.Subscribe(first.listen)
+	set.Subscribe(second.listen)
+
+	set.Add("a")
+	unsubscribeFirst()
+	set.Add("b")
+
+	assert.Equal(t, []Event{{Added: []string{"a"}}}, first.events)
+	assert.Equal(t, []Event{{Added: []string{"a"}}, {Added: []string{"b"}}}, second.events)
+}
+
+func TestCollection_SubscribeChan(t *testing.T) {
+	set := New()
+	events := make(chan Event, 2)
+	unsubscribe := set.SubscribeChan(events)
+	set.AddMany("a", "b")
+	set.Remove("a")
+	unsubscribe()
+	set.Remove("b")
+
+	assert.Equal(t, Event{Added: []string{"a", "b"}}, <-events)
+	assert.Equal(t, Event{Removed: []string{"a"}}, <-events)
+	assert.Len(t, events, 0)
+}
+
+func TestPerItem(t *testing.T) {
+	var added, removed []string
+	set := NewOf("a")
+	set.Subscribe(PerItem(func(v string) {
+		added = append(added, v)
+	}, func(v string) {
+		removed = append(removed, v)
+	}))
+	set.Subscribe(PerItem(nil, nil))
+	set.SymmetricDifferenceWith(string_set.NewOf("a", "b", "c"))
+
+	assert.ElementsMatch(t, []string{"b", "c"}, added)
+	assert.Equal(t, []string{"a"}, removed)
+}
+
+func TestCollection_Immutable(t *testing.T) {
+	var _ string_set.Interface = New()
+	a := NewOf("a", "b")
+	events := &recorder{}
+	a.Subscribe(events.listen)
+	b := string_set.NewOf("b", "c")
+
+	union := a.Union(b)
+	assert.IsType(t, &T{}, union)
+	union.Add("d")
+	assert.Empty(t, events.events, "the new set does not share listeners")
+	assert.True(t, string_set.NewOf("a").IsEqualTo(a.Subtract(b)))
+	assert.True(t, string_set.NewOf("b").IsEqualTo(a.Intersection(NewOf("b"))))
+	assert.True(t, string_set.NewOf("a", "c").IsEqualTo(a.SymmetricDifference(b)))
+	assert.Equal(t, 1, a.IntersectionLen(b))
+	assert.True(t, a.IsSubsetOf(union))
+	assert.True(t, a.IsProperSubsetOf(union))
+	assert.True(t, union.IsSupersetOf(a))
+	assert.False(t, a.IsDisjointFrom(b))
+	assert.True(t, a.IsEqualTo(string_set.NewOf("a", "b")))
+	assert.True(t, string_set.NewOf("a", "b").IsEqualTo(a))
+	assert.Equal(t, []string{"a", "b"}, slices.Collect(a.Sorted()))
+	assert.True(t, a.Any(func(v string) bool { return v == "b" }))
+	assert.True(t, a.None(func(v string) bool { return v == "c" }))
+
+	copied := a.Copy()
+	copied.Remove("a")
+	assert.True(t, a.Includes("a"))
+	assert.Empty(t, events.events)
+	assert.Equal(t, 2, a.Freeze().Len())
+}
@@ -0,0 +1,269 @@
+package string_set_ordered
+
+import (
+	"container/list"
+	"github.com/wojnosystems/go-string-set/string_set"
+	"iter"
+)
+
+const (
+	defaultCapacity = 10
+)
+
+// New creates a new, empty, insertion-ordered string set, with a small default capacity
+func New() *T {
+	return NewWithCapacity(defaultCapacity)
+}
+
+// NewOf is a convenience method to create an insertion-ordered string set containing the items you specify, in the
+// order of their first appearance
+func NewOf(items ...string) *T {
+	ret := NewWithCapacity(len(items))
+	ret.AddMany(items...)
+	return ret
+}
+
+// NewWithCapacity creates a new, empty, insertion-ordered string set with the provided capacity
+func NewWithCapacity(capacity int) *T {
+	return &T{
+		items: make(map[string]*list.Element, capacity),
+		order: list.New(),
+	}
+}
+
+// T holds the underlying string_set_ordered type, do not instantiate this yourself,
+// Please use New, NewOf, or NewWithCapacity
+//
+// T remembers the order items were first added in. Each, EachCancelable, All and ToSlice follow that order. Adding
+// an item that is already in the set does not move it. Strings are compared byte-for-byte
+type T struct {
+	items map[string]*list.Element
+	order *list.List
+}
+
+// Add appends an item to the end of the set. If it already exists, it keeps its position
+func (c *T) Add(v string) {
+	if _, ok := c.items[v]; !ok {
+		c.items[v] = c.order.PushBack(v)
+	}
+}
+
+func (c *T) AddMany(v ...string) {
+	for _, s := range v {
+		c.Add(s)
+	}
+}
+
+func (c *T) Remove(v string) {
+	if e, ok := c.items[v]; ok {
+		c.order.Remove(e)
+		delete(c.items, v)
+	}
+}
+
+func (c *T) RemoveMany(v ...string) {
+	for _, s := range v {
+		c.Remove(s)
+	}
+}
+
+// UnionWith adds the items of o that the set does not already contain, as compared by string_set.CompareKey.
+// Returns the number of items added. They are added in the order o yields them
+func (c *T) UnionWith(o string_set.Immutable) (changed int) {
+	return string_set.UnionWith(c, o)
+}
+
+// SubtractWith removes the items that o contains, as compared by string_set.CompareKey. Returns the number of items
+// removed
+func (c *T) SubtractWith(o string_set.Immutable) (changed int) {
+	return string_set.SubtractWith(c, o)
+}
+
+// IntersectWith removes the items that o does not contain, as compared by string_set.CompareKey. Returns the number of
+// items removed
+func (c *T) IntersectWith(o string_set.Immutable) (changed int) {
+	return string_set.IntersectWith(c, o)
+}
+
+// SymmetricDifferenceWith removes the items that o contains, and adds the items of o that the set did not contain,
+// as compared by string_set.CompareKey. Returns the number of items added or removed
+func (c *T) SymmetricDifferenceWith(o string_set.Immutable) (changed int) {
+	return string_set.SymmetricDifferenceWith(c, o)
+}
+
+// Apply removes the items d removed and adds the items d added, as SubtractWith and UnionWith do. Returns the number
+// of items removed or added
+func (c *T) Apply(d string_set.DiffResult) (changed int) {
+	return c.SubtractWith(d.Removed) + c.UnionWith(d.Added)
+}
+
+// MoveToFront moves v to the start of the order and returns true. If v is not in the set, returns false
+func (c *T) MoveToFront(v string) bool {
+	e, ok := c.items[v]
+	if ok {
+		c.order.MoveToFront(e)
+	}
+	return ok
+}
+
+// MoveToBack moves v to the end of the order and returns true. If v is not in the set, returns false
+func (c *T) MoveToBack(v string) bool {
+	e, ok := c.items[v]
+	if ok {
+		c.order.MoveToBack(e)
+	}
+	return ok
+}
+
+// First returns the item at the start of the order, and true. If the set is empty, returns "" and false
+func (c *T) First() (string, bool) {
+	return valueOf(c.order.Front())
+}
+
+// Last returns the item at the end of the order, and true. If the set is empty, returns "" and false
+func (c *T) Last() (string, bool) {
+	return valueOf(c.order.Back())
+}
+
+func (c *T) Includes(v string) bool {
+	_, ok := c.items[v]
+	return ok
+}
+
+func (c *T) IsEmpty() bool {
+	return c.Len() == 0
+}
+
+func (c *T) Len() int {
+	return len(c.items)
+}
+
+// IsEqualTo returns true if both sets contain the same strings. Order does not matter
+func (c *T) IsEqualTo(o string_set.Immutable) bool {
+	return string_set.IsEqual(c, o)
+}
+
+// Union returns a new ordered set with the items of the callee in their order, followed by the items of o that the
+// callee does not include, in the order o iterates them
+func (c *T) Union(o string_set.Immutable) (out string_set.Interface) {
+	out = c.Copy()
+	string_set.UnionInto(out, c, o)
+	return
+}
+
+// Subtract returns a new ordered set with the items of the callee that are not in o, in the callee's order
+func (c *T) Subtract(o string_set.Immutable) (out string_set.Interface) {
+	out = NewWithCapacity(c.Len())
+	string_set.SubtractInto(out, c, o)
+	return
+}
+
+// Intersection returns a new ordered set with the items of the callee that are also in o, in the callee's order
+func (c *T) Intersection(o string_set.Immutable) (out string_set.Interface) {
+	out = NewWithCapacity(c.Len())
+	string_set.IntersectionInto(out, c, o)
+	return
+}
+
+// SymmetricDifference returns a new ordered set containing the items of either the callee or the parameter that are
+// not in both, as compared by string_set.CompareKey
+func (c *T) SymmetricDifference(o string_set.Immutable) (out string_set.Interface) {
+	out = NewWithCapacity(c.Len())
+	string_set.SymmetricDifferenceInto(out, c, o)
+	return
+}
+
+// IsSubsetOf returns true if o contains every item of the set, as compared by string_set.CompareKey
+func (c *T) IsSubsetOf(o string_set.Immutable) bool {
+	return string_set.IsSubset(c, o)
+}
+
+// IsProperSubsetOf returns true if o contains every item of the set and has items the set does not, as compared
+// by string_set.CompareKey
+func (c *T) IsProperSubsetOf(o string_set.Immutable) bool {
+	return string_set.IsProperSubset(c, o)
+}
+
+// IsSupersetOf returns true if the set contains every item of o, as compared by string_set.CompareKey
+func (c *T) IsSupersetOf(o string_set.Immutable) bool {
+	return string_set.IsSubset(o, c)
+}
+
+// IsDisjointFrom returns true if the set and o have no items in common, as compared by string_set.CompareKey
+func (c *T) IsDisjointFrom(o string_set.Immutable) bool {
+	return string_set.IsDisjoint(c, o)
+}
+
+// IntersectionLen returns the number of items Intersection would return, without creating the intersection
+func (c *T) IntersectionLen(o string_set.Immutable) int {
+	return string_set.IntersectionLen(c, o)
+}
+
+// ToSlice returns the items of the set in order
+func (c *T) ToSlice() (out []string) {
+	out = make([]string, 0, c.Len())
+	for v := range c.All() {
+		out = append(out, v)
+	}
+	return
+}
+
+// Each loops over each string in the set in order
+func (c *T) Each(item func(v string)) {
+	for v := range c.All() {
+		item(v)
+	}
+}
+
+// EachCancelable is just like Each, but you can stop the iteration by returning
+// string_set.Break instead of string_set.Continue
+func (c *T) EachCancelable(item func(v string) (next string_set.NextAction)) {
+	for v := range c.All() {
+		if item(v) == string_set.Break {
+			break
+		}
+	}
+}
+
+// All returns an iterator over the items in the set in order. Items must not be removed or moved while iterating
+func (c *T) All() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for e := c.order.Front(); e != nil; e = e.Next() {
+			if !yield(e.Value.(string)) {
+				return
+			}
+		}
+	}
+}
+
+// Any returns true if predicate returns true for any item. Short-circuits and stops iteration when didMatch
+// returns true. Items are tested in order
+func (c *T) Any(item func(v string) (didMatch bool)) bool {
+	for v := range c.All() {
+		if item(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// None return true if predicate returned false for every item in the set. If predicate returns true, short-circuit
+// and return false from this method, indicating that at least 1 item matched
+func (c *T) None(item func(v string) (didMatch bool)) bool {
+	return !c.Any(item)
+}
+
+// Copy returns a shared-nothing copy of the set with the same order
+func (c *T) Copy() string_set.Interface {
+	outItems := NewWithCapacity(c.Len())
+	c.Each(outItems.Add)
+	return outItems
+}
+
+// valueOf returns the item held by e, or "" and false if e is nil
+func valueOf(e *list.Element) (string, bool) {
+	if e == nil {
+		return "", false
+	}
+	return e.Value.(string), true
+}
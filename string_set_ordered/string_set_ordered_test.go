@@ -0,0 +1,122 @@
+package string_set_ordered
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/wojnosystems/go-string-set/string_set"
+	"slices"
+	"testing"
+)
+
+func TestCollection_Add(t *testing.T) {
+	cases := map[string]struct {
+		input    []string
+		expected []string
+	}{
+		"empty": {
+			input:    []string{},
+			expected: []string{},
+		},
+		"keeps insertion order": {
+			input:    []string{"--verbose", "-o", "out.txt", "-a"},
+			expected: []string{"--verbose", "-o", "out.txt", "-a"},
+		},
+		"duplicate keeps first position": {
+			input:    []string{"c", "a", "b", "c", "a"},
+			expected: []string{"c", "a", "b"},
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			actual := NewOf(c.input...)
+			assert.Equal(t, c.expected, actual.ToSlice())
+			assert.Equal(t, len(c.expected), actual.Len())
+		})
+	}
+}
+
+func TestCollection_Remove(t *testing.T) {
+	set := NewOf("a", "b", "c")
+	set.RemoveMany("b", "x")
+	assert.Equal(t, []string{"a", "c"}, set.ToSlice())
+	set.Add("b")
+	assert.Equal(t, []string{"a", "c", "b"}, set.ToSlice())
+	assert.False(t, set.IsEmpty())
+}
+
+func TestCollection_Move(t *testing.T) {
+	set := NewOf("a", "b", "c")
+	assert.True(t, set.MoveToFront("c"))
+	assert.True(t, set.MoveToBack("a"))
+	assert.False(t, set.MoveToFront("x"))
+	assert.False(t, set.MoveToBack("x"))
+	assert.Equal(t, []string{"c", "b", "a"}, set.ToSlice())
+
+	first, ok := set.First()
+	assert.Equal(t, "c", first)
+	assert.True(t, ok)
+	last, ok := set.Last()
+	assert.Equal(t, "a", last)
+	assert.True(t, ok)
+
+	_, ok = New().First()
+	assert.False(t, ok)
+	_, ok = New().Last()
+	assert.False(t, ok)
+}
+
+func TestCollection_Iterate(t *testing.T) {
+	set := NewOf("c", "a", "b")
+	assert.Equal(t, []string{"c", "a", "b"}, slices.Collect(set.All()))
+
+	var each []string
+	set.Each(func(v string) {
+		each = append(each, v)
+	})
+	assert.Equal(t, []string{"c", "a", "b"}, each)
+
+	each = nil
+	set.EachCancelable(func(v string) string_set.NextAction {
+		each = append(each, v)
+		return string_set.Break
+	})
+	assert.Equal(t, []string{"c"}, each)
+	assert.True(t, set.Any(func(v string) bool { return v == "a" }))
+	assert.True(t, set.None(func(v string) bool { return v == "x" }))
+}
+
+func TestCollection_Setter(t *testing.T) {
+	a := NewOf("d", "b", "a")
+	b := NewOf("c", "a", "e")
+
+	assert.Equal(t, []string{"d", "b", "a", "c", "e"}, a.Union(b).ToSlice())
+	assert.Equal(t, []string{"c", "a", "e", "d", "b"}, b.Union(a).ToSlice())
+	assert.Equal(t, []string{"d", "b"}, a.Subtract(b).ToSlice())
+	assert.Equal(t, []string{"a"}, a.Intersection(b).ToSlice())
+	assert.Equal(t, []string{"d", "b", "c", "e"}, a.SymmetricDifference(b).ToSlice())
+	assert.Equal(t, 1, a.IntersectionLen(b))
+	assert.True(t, a.IsSubsetOf(a.Union(b)))
+	assert.False(t, a.IsDisjointFrom(b))
+	assert.True(t, a.IsEqualTo(string_set.NewOf("a", "b", "d")))
+	assert.True(t, string_set.NewOf("a", "b", "d").IsEqualTo(a))
+}
+
+func TestCollection_InPlaceSetter(t *testing.T) {
+	set := NewOf("d", "b", "a")
+	assert.Equal(t, 2, set.UnionWith(NewOf("c", "a", "e")))
+	assert.Equal(t, []string{"d", "b", "a", "c", "e"}, set.ToSlice())
+	assert.Equal(t, 2, set.SubtractWith(string_set.NewOf("a", "e")))
+	assert.Equal(t, 1, set.IntersectWith(string_set.NewOf("b", "c")))
+	assert.Equal(t, 2, set.SymmetricDifferenceWith(NewOf("b", "a")))
+	assert.Equal(t, []string{"c", "a"}, set.ToSlice())
+	assert.Equal(t, 3, set.Apply(string_set.Diff(NewOf("c"), NewOf("b", "d"))))
+	assert.Equal(t, []string{"a", "b", "d"}, set.ToSlice())
+}
+
+func TestCollection_Copy(t *testing.T) {
+	set := NewOf("b", "a")
+	actual := set.Copy()
+	actual.Add("c")
+	assert.Equal(t, []string{"b", "a"}, set.ToSlice())
+	assert.Equal(t, []string{"b", "a", "c"}, actual.ToSlice())
+}
@@ -0,0 +1,179 @@
+package string_set_persistent
+
+import (
+	"math/bits"
+	"slices"
+)
+
+const (
+	// bitsPerLevel is how many bits of the hash pick a slot at each level of the trie
+	bitsPerLevel = 5
+	slotMask     = 1<<bitsPerLevel - 1
+	hashBits     = 64
+)
+
+// node is a node of a hash array mapped trie. Nodes are never changed once they are reachable from a T, so versions
+// of a set can share every node that an addition or removal did not touch
+type node struct {
+	// bitmap has a bit set for each of the 32 possible slots that is in use
+	bitmap uint32
+	// slots holds the slots in use, in order
+	slots []slot
+	// collisions holds items whose hashes are entirely equal. Only nodes below the last level of the trie use it
+	collisions []string
+}
+
+// slot holds either an item or a child node
+type slot struct {
+	hash  uint64
+	value string
+	child *node
+}
+
+// position returns the bit for the slot the hash selects at shift, and where that slot is, or would be, in slots
+func (n *node) position(hash uint64, shift uint) (bit uint32, pos int) {
+	bit = 1 << ((hash >> shift) & slotMask)
+	return bit, bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+func (n *node) includes(hash uint64, shift uint, v string) bool {
+	for n != nil {
+		if shift >= hashBits {
+			return slices.Contains(n.collisions, v)
+		}
+		bit, pos := n.position(hash, shift)
+		if n.bitmap&bit == 0 {
+			return false
+		}
+		s := n.slots[pos]
+		if s.child == nil {
+			return s.value == v
+		}
+		n, shift = s.child, shift+bitsPerLevel
+	}
+	return false
+}
+
+// with returns a node that also holds v, sharing everything it can with n. Returns n itself if v was already there
+func (n *node) with(hash uint64, shift uint, v string) (out *node, added bool) {
+	if n == nil {
+		n = &node{}
+	}
+	if shift >= hashBits {
+		if slices.Contains(n.collisions, v) {
+			return n, false
+		}
+		return &node{collisions: append(slices.Clip(n.collisions), v)}, true
+	}
+	bit, pos := n.position(hash, shift)
+	if n.bitmap&bit == 0 {
+		out = &node{bitmap: n.bitmap | bit, slots: slices.Insert(slices.Clip(n.slots), pos, slot{hash: hash, value: v})}
+		return out, true
+	}
+	replacement := n.slots[pos]
+	switch {
+	case replacement.child != nil:
+		child, added := replacement.child.with(hash, shift+bitsPerLevel, v)
+		if !added {
+			return n, false
+		}
+		replacement = slot{child: child}
+	case replacement.value == v:
+		return n, false
+	default:
+		child, _ := (*node)(nil).with(replacement.hash, shift+bitsPerLevel, replacement.value)
+		child, _ = child.with(hash, shift+bitsPerLevel, v)
+		replacement = slot{child: child}
+	}
+	return n.replace(pos, replacement), true
+}
+
+// without returns a node that does not hold v, sharing everything it can with n. Returns n itself if v was not
+// there, and nil if the node would be empty
+func (n *node) without(hash uint64, shift uint, v string) (out *node, removed bool) {
+	if n == nil {
+		return nil, false
+	}
+	if shift >= hashBits {
+		i := slices.Index(n.collisions, v)
+		if i < 0 {
+			return n, false
+		}
+		if len(n.collisions) == 1 {
+			return nil, true
+		}
+		return &node{collisions: slices.Delete(slices.Clone(n.collisions), i, i+1)}, true
+	}
+	bit, pos := n.position(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	s := n.slots[pos]
+	if s.child == nil {
+		if s.value != v {
+			return n, false
+		}
+		return n.remove(bit, pos), true
+	}
+	child, removed := s.child.without(hash, shift+bitsPerLevel, v)
+	if !removed {
+		return n, false
+	}
+	if child == nil {
+		return n.remove(bit, pos), true
+	}
+	if only, ok := child.onlyItem(hash); ok {
+		// a child holding a single item is replaced by the item, so the trie stays as shallow as it can
+		return n.replace(pos, only), true
+	}
+	return n.replace(pos, slot{child: child}), true
+}
+
+// onlyItem returns the item held by n if it is n's only content. hash is the hash of any item below n, which for
+// collisions is the hash of them all
+func (n *node) onlyItem(hash uint64) (s slot, ok bool) {
+	switch {
+	case len(n.collisions) == 1:
+		return slot{hash: hash, value: n.collisions[0]}, true
+	case len(n.slots) == 1 && n.slots[0].child == nil:
+		return n.slots[0], true
+	}
+	return slot{}, false
+}
+
+// replace returns a copy of n with the slot at pos replaced
+func (n *node) replace(pos int, s slot) *node {
+	slots := slices.Clone(n.slots)
+	slots[pos] = s
+	return &node{bitmap: n.bitmap, slots: slots}
+}
+
+// remove returns a copy of n without the slot at pos, or nil if it would be empty
+func (n *node) remove(bit uint32, pos int) *node {
+	if len(n.slots) == 1 {
+		return nil
+	}
+	return &node{bitmap: n.bitmap &^ bit, slots: slices.Delete(slices.Clone(n.slots), pos, pos+1)}
+}
+
+// walk calls yield for each item below n. Returns false if yield asked to stop
+func (n *node) walk(yield func(v string) bool) bool {
+	if n == nil {
+		return true
+	}
+	for _, v := range n.collisions {
+		if !yield(v) {
+			return false
+		}
+	}
+	for _, s := range n.slots {
+		if s.child == nil {
+			if !yield(s.value) {
+				return false
+			}
+		} else if !s.child.walk(yield) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,199 @@
+package string_set_persistent
+
+import (
+	"github.com/wojnosystems/go-string-set/string_set"
+	"hash/maphash"
+	"iter"
+)
+
+// seed is shared by every set, so that sets can be compared node by node
+var seed = maphash.MakeSeed()
+
+func hashOf(v string) uint64 {
+	return maphash.String(seed, v)
+}
+
+// Empty is a convenience declaration: it's an empty set you can use to compare
+// to other sets if you want to use IsEqualTo instead of testing with Len. It's also the starting point for
+// building sets with With
+var Empty = &T{}
+
+// NewOf is a convenience method to create a persistent string set containing the items you specify
+func NewOf(items ...string) *T {
+	out := &Transient{T: Empty}
+	out.AddMany(items...)
+	return out.T
+}
+
+// T holds the underlying string_set_persistent type, do not instantiate this yourself,
+// Please use Empty or NewOf
+//
+// T is an immutable set stored in a hash array mapped trie. With and Without return new versions of the set that
+// share all of the trie they did not change with the original, so both are O(log n) in time and memory, and old
+// versions stay valid and unchanged. This makes T cheap to snapshot: Copy is O(1), and returns a Transient, which
+// can be changed like any other string_set.Interface without affecting the set it was copied from. Strings are
+// compared byte-for-byte
+type T struct {
+	root *node
+	len  int
+}
+
+// With returns a version of the set that also contains v. Returns the callee if it already contains v
+func (c *T) With(v string) *T {
+	root, added := c.root.with(hashOf(v), 0, v)
+	if !added {
+		return c
+	}
+	return &T{root: root, len: c.len + 1}
+}
+
+// Without returns a version of the set that does not contain v. Returns the callee if it did not contain v
+func (c *T) Without(v string) *T {
+	root, removed := c.root.without(hashOf(v), 0, v)
+	if !removed {
+		return c
+	}
+	return &T{root: root, len: c.len - 1}
+}
+
+func (c *T) Includes(v string) bool {
+	return c.root.includes(hashOf(v), 0, v)
+}
+
+func (c *T) IsEmpty() bool {
+	return c.len == 0
+}
+
+func (c *T) Len() int {
+	return c.len
+}
+
+// IsEqualTo returns true if both sets contain the same strings. Versions that share their whole trie are equal
+// without comparing their items
+func (c *T) IsEqualTo(o string_set.Immutable) bool {
+	if other, ok := o.(interface{ Snapshot() *T }); ok && other.Snapshot().root == c.root {
+		return true
+	}
+	return string_set.IsEqual(c, o)
+}
+
+// Union returns a new, mutable, set containing all of the items from the callee and the parameter
+func (c *T) Union(o string_set.Immutable) (out string_set.Interface) {
+	out = c.Copy()
+	string_set.UnionInto(out, c, o)
+	return
+}
+
+// Subtract returns a new, mutable, set containing only items from the callee, but without the items in the parameter
+func (c *T) Subtract(o string_set.Immutable) (out string_set.Interface) {
+	out = Empty.Copy()
+	string_set.SubtractInto(out, c, o)
+	return
+}
+
+// Intersection returns a new, mutable, set containing only items common to both the callee and parameter
+func (c *T) Intersection(o string_set.Immutable) (out string_set.Interface) {
+	out = Empty.Copy()
+	string_set.IntersectionInto(out, c, o)
+	return
+}
+
+// SymmetricDifference returns a new set containing the items of either the callee or the parameter that are
+// not in both, as compared by string_set.CompareKey
+func (c *T) SymmetricDifference(o string_set.Immutable) (out string_set.Interface) {
+	out = Empty.Copy()
+	string_set.SymmetricDifferenceInto(out, c, o)
+	return
+}
+
+// IsSubsetOf returns true if o contains every item of the set, as compared by string_set.CompareKey
+func (c *T) IsSubsetOf(o string_set.Immutable) bool {
+	return string_set.IsSubset(c, o)
+}
+
+// IsProperSubsetOf returns true if o contains every item of the set and has items the set does not, as compared
+// by string_set.CompareKey
+func (c *T) IsProperSubsetOf(o string_set.Immutable) bool {
+	return string_set.IsProperSubset(c, o)
+}
+
+// IsSupersetOf returns true if the set contains every item of o, as compared by string_set.CompareKey
+func (c *T) IsSupersetOf(o string_set.Immutable) bool {
+	return string_set.IsSubset(o, c)
+}
+
+// IsDisjointFrom returns true if the set and o have no items in common, as compared by string_set.CompareKey
+func (c *T) IsDisjointFrom(o string_set.Immutable) bool {
+	return string_set.IsDisjoint(c, o)
+}
+
+// IntersectionLen returns the number of items Intersection would return, without creating the intersection
+func (c *T) IntersectionLen(o string_set.Immutable) int {
+	return string_set.IntersectionLen(c, o)
+}
+
+// ToSlice returns the items of the set, in no particular order
+func (c *T) ToSlice() (out []string) {
+	out = make([]string, 0, c.len)
+	for v := range c.All() {
+		out = append(out, v)
+	}
+	return
+}
+
+// Each loops over each string in the set, in no particular order
+func (c *T) Each(item func(v string)) {
+	for v := range c.All() {
+		item(v)
+	}
+}
+
+// EachCancelable is just like Each, but you can stop the iteration by returning
+// string_set.Break instead of string_set.Continue
+func (c *T) EachCancelable(item func(v string) (next string_set.NextAction)) {
+	for v := range c.All() {
+		if item(v) == string_set.Break {
+			break
+		}
+	}
+}
+
+// All returns an iterator over the items in the set, in no particular order
+func (c *T) All() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		c.root.walk(yield)
+	}
+}
+
+// Sorted returns an iterator over the items in the set in lexical order
+func (c *T) Sorted() iter.Seq[string] {
+	return string_set.Collect(c.All()).Sorted()
+}
+
+// Any returns true if predicate returns true for any item. Short-circuits and stops iteration when didMatch
+// returns true
+func (c *T) Any(item func(v string) (didMatch bool)) bool {
+	for v := range c.All() {
+		if item(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// None return true if predicate returned false for every item in the set. If predicate returns true, short-circuit
+// and return false from this method, indicating that at least 1 item matched
+func (c *T) None(item func(v string) (didMatch bool)) bool {
+	return !c.Any(item)
+}
+
+// Copy returns a Transient starting from this version of the set, in O(1). Changes to the Transient do not affect
+// the callee
+func (c *T) Copy() string_set.Interface {
+	return &Transient{T: c}
+}
+
+// Snapshot returns the callee, so that T and Transient can both be snapshot the same way
+func (c *T) Snapshot() *T {
+	return c
+}
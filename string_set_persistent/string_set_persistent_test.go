@@ -0,0 +1,202 @@
+package string_set_persistent
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/wojnosystems/go-string-set/string_set"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestCollection_WithWithout(t *testing.T) {
+	cases := map[string]struct {
+		items    []string
+		with     []string
+		without  []string
+		expected []string
+	}{
+		"empty": {
+			expected: []string{},
+		},
+		"with": {
+			with:     []string{"b", "a", ""},
+			expected: []string{"", "a", "b"},
+		},
+		"with duplicates": {
+			items:    []string{"a"},
+			with:     []string{"a", "b", "b"},
+			expected: []string{"a", "b"},
+		},
+		"without": {
+			items:    []string{"a", "b", "c"},
+			without:  []string{"b", "missing"},
+			expected: []string{"a", "c"},
+		},
+		"without everything": {
+			items:    []string{"a", "b"},
+			without:  []string{"a", "b"},
+			expected: []string{},
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			original := NewOf(c.items...)
+			actual := original
+			for _, v := range c.with {
+				actual = actual.With(v)
+			}
+			for _, v := range c.without {
+				actual = actual.Without(v)
+			}
+			assert.ElementsMatch(t, c.expected, actual.ToSlice())
+			assert.Equal(t, len(c.expected), actual.Len())
+			assert.True(t, original.IsEqualTo(string_set.NewOf(c.items...)))
+		})
+	}
+}
+
+func TestCollection_UnchangedVersionIsCallee(t *testing.T) {
+	set := NewOf("a", "b")
+	assert.Same(t, set, set.With("a"))
+	assert.Same(t, set, set.Without("c"))
+}
+
+func TestCollection_ManyVersions(t *testing.T) {
+	versions := []*T{Empty}
+	for i := 0; i < 2000; i++ {
+		versions = append(versions, versions[i].With(strconv.Itoa(i)))
+	}
+	for i, version := range versions {
+		assert.Equal(t, i, version.Len())
+		assert.True(t, version.Includes(strconv.Itoa(i-1)) || i == 0)
+		assert.False(t, version.Includes(strconv.Itoa(i)))
+	}
+
+	set := versions[len(versions)-1]
+	for i := 0; i < 2000; i += 2 {
+		set = set.Without(strconv.Itoa(i))
+	}
+	assert.Equal(t, 1000, set.Len())
+	assert.Equal(t, 1000, len(set.ToSlice()))
+	for i := 0; i < 2000; i++ {
+		assert.Equal(t, i%2 == 1, set.Includes(strconv.Itoa(i)))
+	}
+	assert.Equal(t, 2000, versions[len(versions)-1].Len())
+}
+
+func TestCollection_Copy(t *testing.T) {
+	set := NewOf("a", "b")
+	copied := set.Copy()
+	copied.Add("c")
+	copied.Remove("a")
+
+	assert.True(t, set.IsEqualTo(string_set.NewOf("a", "b")))
+	assert.True(t, copied.IsEqualTo(string_set.NewOf("b", "c")))
+
+	transient := copied.(*Transient)
+	snapshot := transient.Snapshot()
+	transient.AddMany("d", "e")
+	transient.RemoveMany("b")
+	assert.True(t, snapshot.IsEqualTo(string_set.NewOf("b", "c")))
+	assert.True(t, transient.IsEqualTo(string_set.NewOf("c", "d", "e")))
+	assert.True(t, transient.IsEqualTo(transient.Snapshot()))
+}
+
+func TestCollection_Setter(t *testing.T) {
+	a := NewOf("a", "b", "c")
+	b := string_set.NewOf("b", "c", "d")
+
+	assert.True(t, string_set.NewOf("a", "b", "c", "d").IsEqualTo(a.Union(b)))
+	assert.True(t, string_set.NewOf("a").IsEqualTo(a.Subtract(b)))
+	assert.True(t, string_set.NewOf("b", "c").IsEqualTo(a.Intersection(b)))
+	assert.True(t, string_set.NewOf("a", "d").IsEqualTo(a.SymmetricDifference(b)))
+	assert.Equal(t, 2, a.IntersectionLen(b))
+	assert.True(t, a.IsProperSubsetOf(a.Union(b)))
+	assert.False(t, a.IsDisjointFrom(b))
+	assert.True(t, a.IsEqualTo(NewOf("c", "b", "a")))
+	assert.False(t, a.IsEqualTo(b))
+	assert.IsType(t, &Transient{}, a.Union(b))
+	assert.True(t, a.IsEqualTo(string_set.NewOf("a", "b", "c")))
+
+	assert.Equal(t, []string{"a", "b", "c"}, slices.Collect(a.Sorted()))
+	assert.True(t, a.Any(func(v string) bool { return v == "b" }))
+	assert.True(t, a.None(func(v string) bool { return v == "x" }))
+
+	count := 0
+	a.EachCancelable(func(v string) string_set.NextAction {
+		count++
+		return string_set.Break
+	})
+	assert.Equal(t, 1, count)
+}
+
+func TestCollection_InPlaceSetter(t *testing.T) {
+	transient := NewOf("a", "b", "c").Copy().(*Transient)
+	snapshot := transient.Snapshot()
+	assert.Equal(t, 1, transient.UnionWith(string_set.NewOf("c", "d")))
+	assert.Equal(t, 1, transient.SubtractWith(NewOf("a")))
+	assert.Equal(t, 1, transient.IntersectWith(string_set.NewOf("c", "d", "x")))
+	assert.Equal(t, 2, transient.SymmetricDifferenceWith(NewOf("d", "e")))
+	assert.True(t, string_set.NewOf("c", "e").IsEqualTo(transient))
+	assert.Equal(t, 2, transient.Apply(string_set.Diff(NewOf("c"), NewOf("f"))))
+	assert.True(t, string_set.NewOf("e", "f").IsEqualTo(transient))
+	assert.True(t, string_set.NewOf("a", "b", "c").IsEqualTo(snapshot))
+}
+
+func TestNode_Collisions(t *testing.T) {
+	const hash = 0xdeadbeef
+	var root *node
+	for _, v := range []string{"a", "b", "c"} {
+		root, _ = root.with(hash, 0, v)
+	}
+	root, _ = root.with(hash+1, 0, "d")
+
+	for _, v := range []string{"a", "b", "c"} {
+		assert.True(t, root.includes(hash, 0, v))
+	}
+	assert.False(t, root.includes(hash, 0, "d"))
+	assert.True(t, root.includes(hash+1, 0, "d"))
+
+	same, added := root.with(hash, 0, "b")
+	assert.False(t, added)
+	assert.Same(t, root, same)
+
+	smaller, removed := root.without(hash, 0, "b")
+	assert.True(t, removed)
+	assert.True(t, root.includes(hash, 0, "b"))
+	assert.False(t, smaller.includes(hash, 0, "b"))
+	smaller, _ = smaller.without(hash, 0, "a")
+	smaller, _ = smaller.without(hash+1, 0, "d")
+	assert.True(t, smaller.includes(hash, 0, "c"))
+	assert.Len(t, smaller.slots, 1)
+	assert.Nil(t, smaller.slots[0].child, "single remaining item should be pulled up to the root")
+
+	var items []string
+	root.walk(func(v string) bool {
+		items = append(items, v)
+		return true
+	})
+	assert.ElementsMatch(t, []string{"a", "b", "c", "d"}, items)
+}
+
+func BenchmarkCopy(b *testing.B) {
+	words := make([]string, 100000)
+	for i := range words {
+		words[i] = "word-" + strconv.Itoa(i)
+	}
+	b.Run("string_set", func(b *testing.B) {
+		set := string_set.NewOf(words...)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			set.Copy().Add("x")
+		}
+	})
+	b.Run("persistent", func(b *testing.B) {
+		set := NewOf(words...)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			set.Copy().Add("x")
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package string_set_persistent
+
+import (
+	"github.com/wojnosystems/go-string-set/string_set"
+)
+
+// Transient is a mutable set built on T. Each change replaces the version of the set it holds with a new one, so
+// versions returned by Snapshot are never affected by later changes. Like string_set.T, it is not safe for
+// concurrent use
+//
+// Get one with T.Copy
+type Transient struct {
+	*T
+}
+
+// Add the string to the set
+func (c *Transient) Add(v string) {
+	c.T = c.T.With(v)
+}
+
+// AddMany strings to the set
+func (c *Transient) AddMany(items ...string) {
+	for _, item := range items {
+		c.Add(item)
+	}
+}
+
+// Remove the string from the set, if it's there
+func (c *Transient) Remove(v string) {
+	c.T = c.T.Without(v)
+}
+
+// RemoveMany strings from the set, if they are there
+func (c *Transient) RemoveMany(items ...string) {
+	for _, item := range items {
+		c.Remove(item)
+	}
+}
+
+// UnionWith adds the items of o that the set does not already contain, as compared by string_set.CompareKey.
+// Returns the number of items added
+func (c *Transient) UnionWith(o string_set.Immutable) (changed int) {
+	return string_set.UnionWith(c, o)
+}
+
+// SubtractWith removes the items that o contains, as compared by string_set.CompareKey. Returns the number of items
+// removed
+func (c *Transient) SubtractWith(o string_set.Immutable) (changed int) {
+	return string_set.SubtractWith(c, o)
+}
+
+// IntersectWith removes the items that o does not contain, as compared by string_set.CompareKey. Returns the number of
+// items removed
+func (c *Transient) IntersectWith(o string_set.Immutable) (changed int) {
+	return string_set.IntersectWith(c, o)
+}
+
+// SymmetricDifferenceWith removes the items that o contains, and adds the items of o that the set did not contain,
+// as compared by string_set.CompareKey. Returns the number of items added or removed
+func (c *Transient) SymmetricDifferenceWith(o string_set.Immutable) (changed int) {
+	return string_set.SymmetricDifferenceWith(c, o)
+}
+
+// Apply removes the items d removed and adds the items d added, as SubtractWith and UnionWith do. Returns the number
+// of items removed or added
+func (c *Transient) Apply(d string_set.DiffResult) (changed int) {
+	return c.SubtractWith(d.Removed) + c.UnionWith(d.Added)
+}
+
+// Snapshot returns the current version of the set, in O(1)
+func (c *Transient) Snapshot() *T {
+	return c.T
+}
@@ -0,0 +1,354 @@
+package string_set_sorted
+
+import (
+	"github.com/wojnosystems/go-string-set/string_set"
+	"iter"
+	"slices"
+)
+
+// New creates a new, empty, sorted string set
+func New() *T {
+	return &T{}
+}
+
+// NewOf is a convenience method to create a sorted string set containing the items you specify
+func NewOf(items ...string) *T {
+	sorted := slices.Clone(items)
+	slices.Sort(sorted)
+	return &T{
+		root: build(slices.Compact(sorted)),
+	}
+}
+
+// T holds the underlying string_set_sorted type, do not instantiate this yourself,
+// Please use New or NewOf
+//
+// T keeps its items in lexical (byte-wise) order in a balanced binary tree. Includes, Add and Remove are O(log n),
+// and Each, EachCancelable, All and ToSlice visit the items in order. Strings are compared byte-for-byte
+type T struct {
+	root *node
+}
+
+func (c *T) Add(v string) {
+	c.root, _ = insert(c.root, v)
+}
+
+func (c *T) AddMany(v ...string) {
+	for _, s := range v {
+		c.Add(s)
+	}
+}
+
+func (c *T) Remove(v string) {
+	c.root, _ = remove(c.root, v)
+}
+
+func (c *T) RemoveMany(v ...string) {
+	for _, s := range v {
+		c.Remove(s)
+	}
+}
+
+// UnionWith adds the items of o that the set does not already contain, as compared by string_set.CompareKey.
+// Returns the number of items added
+func (c *T) UnionWith(o string_set.Immutable) (changed int) {
+	return string_set.UnionWith(c, o)
+}
+
+// SubtractWith removes the items that o contains, as compared by string_set.CompareKey. Returns the number of items
+// removed
+func (c *T) SubtractWith(o string_set.Immutable) (changed int) {
+	return string_set.SubtractWith(c, o)
+}
+
+// IntersectWith removes the items that o does not contain, as compared by string_set.CompareKey. Returns the number of
+// items removed
+func (c *T) IntersectWith(o string_set.Immutable) (changed int) {
+	return string_set.IntersectWith(c, o)
+}
+
+// SymmetricDifferenceWith removes the items that o contains, and adds the items of o that the set did not contain,
+// as compared by string_set.CompareKey. Returns the number of items added or removed
+func (c *T) SymmetricDifferenceWith(o string_set.Immutable) (changed int) {
+	return string_set.SymmetricDifferenceWith(c, o)
+}
+
+// Apply removes the items d removed and adds the items d added, as SubtractWith and UnionWith do. Returns the number
+// of items removed or added
+func (c *T) Apply(d string_set.DiffResult) (changed int) {
+	return c.SubtractWith(d.Removed) + c.UnionWith(d.Added)
+}
+
+func (c *T) Includes(v string) bool {
+	return find(c.root, v) != nil
+}
+
+func (c *T) IsEmpty() bool {
+	return c.root == nil
+}
+
+func (c *T) Len() int {
+	return sizeOf(c.root)
+}
+
+// IsEqualTo returns true if both sets contain the same strings. If o is also sorted, the sets are compared with a
+// single linear pass
+func (c *T) IsEqualTo(o string_set.Immutable) bool {
+	if other, ok := o.(*T); ok {
+		return c.Len() == other.Len() && slices.Equal(c.ToSlice(), other.ToSlice())
+	}
+	return string_set.IsEqual(c, o)
+}
+
+// Union returns a new sorted set containing all of the items from the callee and the parameter. If o is also
+// sorted, the sets are merged in linear time
+func (c *T) Union(o string_set.Immutable) string_set.Interface {
+	if other, ok := o.(*T); ok {
+		return fromSorted(merge(c.ToSlice(), other.ToSlice(), true, true, true))
+	}
+	out := c.Copy()
+	string_set.UnionInto(out, c, o)
+	return out
+}
+
+// Subtract returns a new sorted set containing only items from the callee, but without the items in the parameter.
+// If o is also sorted, the sets are merged in linear time
+func (c *T) Subtract(o string_set.Immutable) string_set.Interface {
+	if other, ok := o.(*T); ok {
+		return fromSorted(merge(c.ToSlice(), other.ToSlice(), true, false, false))
+	}
+	out := New()
+	string_set.SubtractInto(out, c, o)
+	return out
+}
+
+// Intersection returns a new sorted set containing only items common to both the callee and parameter. If o is
+// also sorted, the sets are merged in linear time
+func (c *T) Intersection(o string_set.Immutable) string_set.Interface {
+	if other, ok := o.(*T); ok {
+		return fromSorted(merge(c.ToSlice(), other.ToSlice(), false, true, false))
+	}
+	out := New()
+	string_set.IntersectionInto(out, c, o)
+	return out
+}
+
+// SymmetricDifference returns a new sorted set containing the items of either the callee or the parameter that are
+// not in both, as compared by string_set.CompareKey. If o is also sorted, the sets are merged in linear time
+func (c *T) SymmetricDifference(o string_set.Immutable) (out string_set.Interface) {
+	if other, ok := o.(*T); ok {
+		return fromSorted(merge(c.ToSlice(), other.ToSlice(), true, false, true))
+	}
+	out = New()
+	string_set.SymmetricDifferenceInto(out, c, o)
+	return
+}
+
+// IsSubsetOf returns true if o contains every item of the set, as compared by string_set.CompareKey
+func (c *T) IsSubsetOf(o string_set.Immutable) bool {
+	return string_set.IsSubset(c, o)
+}
+
+// IsProperSubsetOf returns true if o contains every item of the set and has items the set does not, as compared
+// by string_set.CompareKey
+func (c *T) IsProperSubsetOf(o string_set.Immutable) bool {
+	return string_set.IsProperSubset(c, o)
+}
+
+// IsSupersetOf returns true if the set contains every item of o, as compared by string_set.CompareKey
+func (c *T) IsSupersetOf(o string_set.Immutable) bool {
+	return string_set.IsSubset(o, c)
+}
+
+// IsDisjointFrom returns true if the set and o have no items in common, as compared by string_set.CompareKey
+func (c *T) IsDisjointFrom(o string_set.Immutable) bool {
+	return string_set.IsDisjoint(c, o)
+}
+
+// IntersectionLen returns the number of items Intersection would return, without creating the intersection
+func (c *T) IntersectionLen(o string_set.Immutable) int {
+	return string_set.IntersectionLen(c, o)
+}
+
+// ToSlice returns the items of the set in lexical order
+func (c *T) ToSlice() (out []string) {
+	out = make([]string, 0, c.Len())
+	for v := range c.All() {
+		out = append(out, v)
+	}
+	return
+}
+
+// Each loops over each string in the set in lexical order
+func (c *T) Each(item func(v string)) {
+	for v := range c.All() {
+		item(v)
+	}
+}
+
+// EachCancelable is just like Each, but you can stop the iteration by returning
+// string_set.Break instead of string_set.Continue
+func (c *T) EachCancelable(item func(v string) (next string_set.NextAction)) {
+	for v := range c.All() {
+		if item(v) == string_set.Break {
+			break
+		}
+	}
+}
+
+// All returns an iterator over the items in the set in lexical order
+func (c *T) All() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		walk(c.root, nil, nil, yield)
+	}
+}
+
+// Sorted is the same as All, and is provided so that T can be used wherever the other sets' Sorted is
+func (c *T) Sorted() iter.Seq[string] {
+	return c.All()
+}
+
+// Range returns an iterator over the items v where from <= v < to, in lexical order
+func (c *T) Range(from, to string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		walk(c.root, &from, &to, yield)
+	}
+}
+
+// Min returns the lexically smallest item, and true. If the set is empty, returns "" and false
+func (c *T) Min() (v string, ok bool) {
+	n := c.root
+	if n == nil {
+		return "", false
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n.value, true
+}
+
+// Max returns the lexically largest item, and true. If the set is empty, returns "" and false
+func (c *T) Max() (v string, ok bool) {
+	n := c.root
+	if n == nil {
+		return "", false
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.value, true
+}
+
+// Floor returns the largest item that is less than or equal to v, and true. If there is none, returns "" and false
+func (c *T) Floor(v string) (floor string, ok bool) {
+	for n := c.root; n != nil; {
+		switch {
+		case v < n.value:
+			n = n.left
+		case v > n.value:
+			floor, ok = n.value, true
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+	return
+}
+
+// Ceiling returns the smallest item that is greater than or equal to v, and true. If there is none, returns "" and
+// false
+func (c *T) Ceiling(v string) (ceiling string, ok bool) {
+	for n := c.root; n != nil; {
+		switch {
+		case v < n.value:
+			ceiling, ok = n.value, true
+			n = n.left
+		case v > n.value:
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+	return
+}
+
+// Rank returns the number of items in the set that are less than v. If v is in the set, that is its zero-based
+// position in lexical order
+func (c *T) Rank(v string) (rank int) {
+	for n := c.root; n != nil; {
+		switch {
+		case v < n.value:
+			n = n.left
+		case v > n.value:
+			rank += sizeOf(n.left) + 1
+			n = n.right
+		default:
+			return rank + sizeOf(n.left)
+		}
+	}
+	return
+}
+
+// Any returns true if predicate returns true for any item. Short-circuits and stops iteration when didMatch
+// returns true. Items are tested in lexical order
+func (c *T) Any(item func(v string) (didMatch bool)) bool {
+	for v := range c.All() {
+		if item(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// None return true if predicate returned false for every item in the set. If predicate returns true, short-circuit
+// and return false from this method, indicating that at least 1 item matched
+func (c *T) None(item func(v string) (didMatch bool)) bool {
+	return !c.Any(item)
+}
+
+// Copy returns a shared-nothing, sorted copy of the set
+func (c *T) Copy() string_set.Interface {
+	return &T{
+		root: clone(c.root),
+	}
+}
+
+// fromSorted creates a set from items, which must be sorted and free of duplicates
+func fromSorted(items []string) *T {
+	return &T{
+		root: build(items),
+	}
+}
+
+// merge walks the sorted, duplicate-free slices a and b together and returns, in order, the items that are only in
+// a if keepA, in both if keepBoth, and only in b if keepB
+func merge(a, b []string, keepA, keepBoth, keepB bool) (out []string) {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			if keepA {
+				out = append(out, a[i])
+			}
+			i++
+		case a[i] > b[j]:
+			if keepB {
+				out = append(out, b[j])
+			}
+			j++
+		default:
+			if keepBoth {
+				out = append(out, a[i])
+			}
+			i++
+			j++
+		}
+	}
+	if keepA {
+		out = append(out, a[i:]...)
+	}
+	if keepB {
+		out = append(out, b[j:]...)
+	}
+	return
+}
@@ -0,0 +1,229 @@
+package string_set_sorted
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/wojnosystems/go-string-set/string_set"
+	"math/rand"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestCollection_AddRemove(t *testing.T) {
+	set := New()
+	expected := string_set.New()
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		v := strconv.Itoa(random.Intn(500))
+		if random.Intn(3) == 0 {
+			set.Remove(v)
+			expected.Remove(v)
+		} else {
+			set.Add(v)
+			expected.Add(v)
+		}
+		assert.Equal(t, expected.Len(), set.Len())
+	}
+	assert.True(t, set.IsEqualTo(expected))
+	assert.True(t, expected.IsEqualTo(set))
+	assert.True(t, slices.IsSorted(set.ToSlice()))
+	assert.LessOrEqual(t, heightOf(set.root), 2*bitsLen(set.Len()))
+}
+
+func bitsLen(n int) (bits int) {
+	for ; n > 0; n >>= 1 {
+		bits++
+	}
+	return
+}
+
+func TestCollection_Ordered(t *testing.T) {
+	set := NewOf("d", "b", "a", "c", "b")
+	assert.Equal(t, []string{"a", "b", "c", "d"}, set.ToSlice())
+	assert.Equal(t, []string{"a", "b", "c", "d"}, slices.Collect(set.All()))
+
+	var each []string
+	set.EachCancelable(func(v string) string_set.NextAction {
+		each = append(each, v)
+		if v == "b" {
+			return string_set.Break
+		}
+		return string_set.Continue
+	})
+	assert.Equal(t, []string{"a", "b"}, each)
+}
+
+func TestCollection_Queries(t *testing.T) {
+	set := NewOf("b", "d", "f", "h")
+	empty := New()
+
+	min, ok := set.Min()
+	assert.Equal(t, "b", min)
+	assert.True(t, ok)
+	max, ok := set.Max()
+	assert.Equal(t, "h", max)
+	assert.True(t, ok)
+	_, ok = empty.Min()
+	assert.False(t, ok)
+	_, ok = empty.Max()
+	assert.False(t, ok)
+
+	cases := map[string]struct {
+		v            string
+		floor        string
+		floorFound   bool
+		ceiling      string
+		ceilingFound bool
+		rank         int
+	}{
+		"before first": {
+			v:            "a",
+			ceiling:      "b",
+			ceilingFound: true,
+		},
+		"exact": {
+			v:            "d",
+			floor:        "d",
+			floorFound:   true,
+			ceiling:      "d",
+			ceilingFound: true,
+			rank:         1,
+		},
+		"between": {
+			v:            "e",
+			floor:        "d",
+			floorFound:   true,
+			ceiling:      "f",
+			ceilingFound: true,
+			rank:         2,
+		},
+		"after last": {
+			v:          "z",
+			floor:      "h",
+			floorFound: true,
+			rank:       4,
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			floor, ok := set.Floor(c.v)
+			assert.Equal(t, c.floor, floor)
+			assert.Equal(t, c.floorFound, ok)
+			ceiling, ok := set.Ceiling(c.v)
+			assert.Equal(t, c.ceiling, ceiling)
+			assert.Equal(t, c.ceilingFound, ok)
+			assert.Equal(t, c.rank, set.Rank(c.v))
+		})
+	}
+}
+
+func TestCollection_Range(t *testing.T) {
+	set := NewOf("app/a", "app/b", "app/c", "lib/a", "zoo")
+	cases := map[string]struct {
+		from     string
+		to       string
+		expected []string
+	}{
+		"prefix": {
+			from:     "app/",
+			to:       "app0",
+			expected: []string{"app/a", "app/b", "app/c"},
+		},
+		"to is exclusive": {
+			from:     "app/b",
+			to:       "lib/a",
+			expected: []string{"app/b", "app/c"},
+		},
+		"empty range": {
+			from: "m",
+			to:   "n",
+		},
+		"everything": {
+			from:     "",
+			to:       "~",
+			expected: []string{"app/a", "app/b", "app/c", "lib/a", "zoo"},
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			assert.Equal(t, c.expected, slices.Collect(set.Range(c.from, c.to)))
+		})
+	}
+}
+
+func TestCollection_Setter(t *testing.T) {
+	cases := map[string]struct {
+		a                   *T
+		b                   string_set.Immutable
+		union               []string
+		subtract            []string
+		intersection        []string
+		symmetricDifference []string
+	}{
+		"both sorted": {
+			a:                   NewOf("a", "b", "d"),
+			b:                   NewOf("b", "c", "e"),
+			union:               []string{"a", "b", "c", "d", "e"},
+			subtract:            []string{"a", "d"},
+			intersection:        []string{"b"},
+			symmetricDifference: []string{"a", "c", "d", "e"},
+		},
+		"unsorted operand": {
+			a:                   NewOf("a", "b", "d"),
+			b:                   string_set.NewOf("b", "c", "e"),
+			union:               []string{"a", "b", "c", "d", "e"},
+			subtract:            []string{"a", "d"},
+			intersection:        []string{"b"},
+			symmetricDifference: []string{"a", "c", "d", "e"},
+		},
+		"empty": {
+			a:                   New(),
+			b:                   NewOf("a"),
+			union:               []string{"a"},
+			subtract:            []string{},
+			symmetricDifference: []string{"a"},
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			union := c.a.Union(c.b)
+			assert.IsType(t, &T{}, union)
+			assert.Equal(t, c.union, union.ToSlice())
+			assert.True(t, string_set.NewOf(c.subtract...).IsEqualTo(c.a.Subtract(c.b)))
+			assert.True(t, string_set.NewOf(c.intersection...).IsEqualTo(c.a.Intersection(c.b)))
+			symmetricDifference := c.a.SymmetricDifference(c.b)
+			assert.IsType(t, &T{}, symmetricDifference)
+			assert.Equal(t, c.symmetricDifference, symmetricDifference.ToSlice())
+			assert.Equal(t, len(c.intersection), c.a.IntersectionLen(c.b))
+			assert.Equal(t, len(c.intersection) == 0, c.a.IsDisjointFrom(c.b))
+			assert.True(t, c.a.IsSubsetOf(union))
+		})
+	}
+}
+
+func TestCollection_InPlaceSetter(t *testing.T) {
+	set := NewOf("a", "b", "d")
+	assert.Equal(t, 2, set.UnionWith(string_set.NewOf("c", "e", "a")))
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, set.ToSlice())
+	assert.Equal(t, 2, set.SubtractWith(NewOf("a", "e")))
+	assert.Equal(t, 1, set.IntersectWith(NewOf("b", "c")))
+	assert.Equal(t, 2, set.SymmetricDifferenceWith(NewOf("b", "a")))
+	assert.Equal(t, []string{"a", "c"}, set.ToSlice())
+	assert.Equal(t, 2, set.Apply(string_set.Diff(NewOf("a", "b"), NewOf("b", "e"))))
+	assert.Equal(t, []string{"c", "e"}, set.ToSlice())
+	assert.Equal(t, 2, set.SubtractWith(set))
+	assert.True(t, set.IsEmpty())
+}
+
+func TestCollection_Copy(t *testing.T) {
+	set := NewOf("a", "b")
+	actual := set.Copy()
+	actual.Add("c")
+	assert.Equal(t, 2, set.Len())
+	assert.Equal(t, []string{"a", "b", "c"}, actual.ToSlice())
+	assert.True(t, set.Any(func(v string) bool { return v == "b" }))
+	assert.True(t, set.None(func(v string) bool { return v == "c" }))
+}
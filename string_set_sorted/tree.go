@@ -0,0 +1,179 @@
+package string_set_sorted
+
+// node is a node of an AVL tree. Each node also records the size of its subtree so that Rank is O(log n)
+type node struct {
+	value       string
+	left, right *node
+	height      int
+	size        int
+}
+
+func heightOf(n *node) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func sizeOf(n *node) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// update recalculates the height and size of n from its children
+func (n *node) update() {
+	n.height = 1 + max(heightOf(n.left), heightOf(n.right))
+	n.size = 1 + sizeOf(n.left) + sizeOf(n.right)
+}
+
+func rotateRight(n *node) *node {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.update()
+	l.update()
+	return l
+}
+
+func rotateLeft(n *node) *node {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.update()
+	r.update()
+	return r
+}
+
+// rebalance restores the AVL invariant at n after one of its subtrees changed height by at most 1
+func rebalance(n *node) *node {
+	n.update()
+	switch balance := heightOf(n.left) - heightOf(n.right); {
+	case balance > 1:
+		if heightOf(n.left.left) < heightOf(n.left.right) {
+			n.left = rotateLeft(n.left)
+		}
+		return rotateRight(n)
+	case balance < -1:
+		if heightOf(n.right.right) < heightOf(n.right.left) {
+			n.right = rotateRight(n.right)
+		}
+		return rotateLeft(n)
+	}
+	return n
+}
+
+// insert adds v to the tree rooted at n, returning the new root and whether v was not already present
+func insert(n *node, v string) (root *node, added bool) {
+	if n == nil {
+		return &node{value: v, height: 1, size: 1}, true
+	}
+	switch {
+	case v < n.value:
+		n.left, added = insert(n.left, v)
+	case v > n.value:
+		n.right, added = insert(n.right, v)
+	default:
+		return n, false
+	}
+	if !added {
+		return n, false
+	}
+	return rebalance(n), true
+}
+
+// remove deletes v from the tree rooted at n, returning the new root and whether v was present
+func remove(n *node, v string) (root *node, removed bool) {
+	if n == nil {
+		return nil, false
+	}
+	switch {
+	case v < n.value:
+		n.left, removed = remove(n.left, v)
+	case v > n.value:
+		n.right, removed = remove(n.right, v)
+	default:
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+		successor := n.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+		n.value = successor.value
+		n.right, _ = remove(n.right, successor.value)
+		removed = true
+	}
+	if !removed {
+		return n, false
+	}
+	return rebalance(n), true
+}
+
+// find returns the node holding v, or nil
+func find(n *node, v string) *node {
+	for n != nil {
+		switch {
+		case v < n.value:
+			n = n.left
+		case v > n.value:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// build creates a balanced tree from items, which must be sorted and free of duplicates. O(n)
+func build(items []string) *node {
+	if len(items) == 0 {
+		return nil
+	}
+	mid := len(items) / 2
+	n := &node{
+		value: items[mid],
+		left:  build(items[:mid]),
+		right: build(items[mid+1:]),
+	}
+	n.update()
+	return n
+}
+
+// clone returns a deep copy of the tree rooted at n
+func clone(n *node) *node {
+	if n == nil {
+		return nil
+	}
+	return &node{
+		value:  n.value,
+		left:   clone(n.left),
+		right:  clone(n.right),
+		height: n.height,
+		size:   n.size,
+	}
+}
+
+// walk calls yield for each value in the tree rooted at n that is within [from, to), in order. A nil bound is
+// unbounded. Returns false if yield asked to stop
+func walk(n *node, from, to *string, yield func(v string) bool) bool {
+	if n == nil {
+		return true
+	}
+	aboveFrom := from == nil || *from <= n.value
+	belowTo := to == nil || n.value < *to
+	if aboveFrom && !walk(n.left, from, to, yield) {
+		return false
+	}
+	if aboveFrom && belowTo && !yield(n.value) {
+		return false
+	}
+	if belowTo {
+		return walk(n.right, from, to, yield)
+	}
+	return true
+}
@@ -0,0 +1,346 @@
+package string_set_sync
+
+import (
+	"github.com/wojnosystems/go-string-set/string_set"
+	"iter"
+	"slices"
+	"sync"
+	"unsafe"
+)
+
+const (
+	defaultCapacity = 10
+)
+
+// New creates a new String set that is safe for concurrent use, with a small default capacity
+func New() *T {
+	return NewWithCapacity(defaultCapacity)
+}
+
+// NewOf is a convenience method to create a concurrent string set containing the items you specify
+func NewOf(items ...string) *T {
+	ret := NewWithCapacity(len(items))
+	ret.AddMany(items...)
+	return ret
+}
+
+// NewWithCapacity creates a new, empty, concurrent string set with the provided capacity
+func NewWithCapacity(capacity int) *T {
+	return &T{
+		set: *string_set.NewWithCapacity(capacity),
+	}
+}
+
+// T holds the underlying string_set_sync type, do not instantiate this yourself,
+// Please use New, NewOf, or NewWithCapacity. The zero value is an empty set ready to use, so T may be embedded by
+// value alongside the data it guards
+//
+// All methods are safe to call from multiple goroutines. Reads share a read-lock, writes take the write-lock.
+type T struct {
+	mu  sync.RWMutex
+	set string_set.T
+}
+
+func (c *T) Add(v string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set.Add(v)
+}
+
+func (c *T) AddMany(v ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set.AddMany(v...)
+}
+
+func (c *T) Remove(v string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set.Remove(v)
+}
+
+func (c *T) RemoveMany(v ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set.RemoveMany(v...)
+}
+
+// UnionWith adds the items of o to the set, without creating a new set. Returns the number of items added. The set
+// is write-locked, and if o is also a *T, it is read-locked, for the duration of the operation
+func (c *T) UnionWith(o string_set.Immutable) (changed int) {
+	unlock := c.lockWith(o)
+	defer unlock()
+	return c.set.UnionWith(unwrap(o))
+}
+
+// SubtractWith removes the items that o contains, without creating a new set. Returns the number of items removed.
+// The set is write-locked, and if o is also a *T, it is read-locked, for the duration of the operation
+func (c *T) SubtractWith(o string_set.Immutable) (changed int) {
+	unlock := c.lockWith(o)
+	defer unlock()
+	return c.set.SubtractWith(unwrap(o))
+}
+
+// IntersectWith removes the items that o does not contain, without creating a new set. Returns the number of items
+// removed. The set is write-locked, and if o is also a *T, it is read-locked, for the duration of the operation
+func (c *T) IntersectWith(o string_set.Immutable) (changed int) {
+	unlock := c.lockWith(o)
+	defer unlock()
+	return c.set.IntersectWith(unwrap(o))
+}
+
+// SymmetricDifferenceWith removes the items that o contains, and adds the items of o that the set did not contain,
+// without creating a new set. Returns the number of items added or removed. The set is write-locked, and if o is
+// also a *T, it is read-locked, for the duration of the operation
+func (c *T) SymmetricDifferenceWith(o string_set.Immutable) (changed int) {
+	unlock := c.lockWith(o)
+	defer unlock()
+	return c.set.SymmetricDifferenceWith(unwrap(o))
+}
+
+// Apply removes the items d removed and adds the items d added, as SubtractWith and UnionWith do. Returns the number
+// of items removed or added. The sets of d are copied first, then the set is write-locked while the changes are
+// made, so other goroutines never see them half applied
+func (c *T) Apply(d string_set.DiffResult) (changed int) {
+	removed, added := d.Removed.Copy(), d.Added.Copy()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.set.Apply(string_set.DiffResult{
+		Added:     unwrap(added),
+		Removed:   unwrap(removed),
+		Unchanged: d.Unchanged,
+	})
+}
+
+func (c *T) Includes(v string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.Includes(v)
+}
+
+func (c *T) IsEmpty() bool {
+	return c.Len() == 0
+}
+
+func (c *T) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.Len()
+}
+
+// IsEqualTo returns true both sets contain the same strings. If o is also a *T, both sets are read-locked for the
+// duration of the comparison, so the result reflects a single moment in time
+func (c *T) IsEqualTo(o string_set.Immutable) bool {
+	unlock := c.rLockWith(o)
+	defer unlock()
+	return c.set.IsEqualTo(unwrap(o))
+}
+
+// Union returns a new concurrent set containing all of the items from the callee and the parameter. If o is also a
+// *T, both sets are read-locked for the duration of the operation
+func (c *T) Union(o string_set.Immutable) string_set.Interface {
+	unlock := c.rLockWith(o)
+	defer unlock()
+	return wrap(c.set.Union(unwrap(o)))
+}
+
+// Subtract returns a new concurrent set containing only items from the callee, but without the items in the
+// parameter. If o is also a *T, both sets are read-locked for the duration of the operation
+func (c *T) Subtract(o string_set.Immutable) string_set.Interface {
+	unlock := c.rLockWith(o)
+	defer unlock()
+	return wrap(c.set.Subtract(unwrap(o)))
+}
+
+// Intersection returns a new concurrent set containing only items common to both the callee and parameter. If o is
+// also a *T, both sets are read-locked for the duration of the operation
+func (c *T) Intersection(o string_set.Immutable) string_set.Interface {
+	unlock := c.rLockWith(o)
+	defer unlock()
+	return wrap(c.set.Intersection(unwrap(o)))
+}
+
+// SymmetricDifference returns a new concurrent set containing the items of either the callee or the parameter that
+// are not in both. If o is also a *T, both sets are read-locked for the duration of the operation
+func (c *T) SymmetricDifference(o string_set.Immutable) string_set.Interface {
+	unlock := c.rLockWith(o)
+	defer unlock()
+	return wrap(c.set.SymmetricDifference(unwrap(o)))
+}
+
+// IsSubsetOf returns true if o contains every item of the set. If o is also a *T, both sets are read-locked for the
+// duration of the comparison
+func (c *T) IsSubsetOf(o string_set.Immutable) bool {
+	unlock := c.rLockWith(o)
+	defer unlock()
+	return c.set.IsSubsetOf(unwrap(o))
+}
+
+// IsProperSubsetOf returns true if o contains every item of the set and has items the set does not. If o is also a
+// *T, both sets are read-locked for the duration of the comparison
+func (c *T) IsProperSubsetOf(o string_set.Immutable) bool {
+	unlock := c.rLockWith(o)
+	defer unlock()
+	return c.set.IsProperSubsetOf(unwrap(o))
+}
+
+// IsSupersetOf returns true if the set contains every item of o. If o is also a *T, both sets are read-locked for
+// the duration of the comparison
+func (c *T) IsSupersetOf(o string_set.Immutable) bool {
+	unlock := c.rLockWith(o)
+	defer unlock()
+	return c.set.IsSupersetOf(unwrap(o))
+}
+
+// IsDisjointFrom returns true if the set and o have no items in common. If o is also a *T, both sets are
+// read-locked for the duration of the comparison
+func (c *T) IsDisjointFrom(o string_set.Immutable) bool {
+	unlock := c.rLockWith(o)
+	defer unlock()
+	return c.set.IsDisjointFrom(unwrap(o))
+}
+
+// IntersectionLen returns the number of items Intersection would return, without creating the intersection. If o is
+// also a *T, both sets are read-locked for the duration of the operation
+func (c *T) IntersectionLen(o string_set.Immutable) int {
+	unlock := c.rLockWith(o)
+	defer unlock()
+	return c.set.IntersectionLen(unwrap(o))
+}
+
+func (c *T) ToSlice() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.ToSlice()
+}
+
+// Each loops over a snapshot of the set taken when Each is called. No lock is held while item runs, so item may
+// read or modify this set, but it will not observe its own changes or those made by other goroutines
+func (c *T) Each(item func(v string)) {
+	for _, v := range c.ToSlice() {
+		item(v)
+	}
+}
+
+// EachCancelable is just like Each, but you can stop the iteration by returning
+// string_set.Break instead of string_set.Continue. It also iterates over a snapshot
+func (c *T) EachCancelable(item func(v string) (next string_set.NextAction)) {
+	for _, v := range c.ToSlice() {
+		if item(v) == string_set.Break {
+			break
+		}
+	}
+}
+
+// All returns an iterator over a snapshot of the set taken when iteration begins, see Each
+func (c *T) All() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for _, v := range c.ToSlice() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Sorted returns an iterator over a snapshot of the set in lexical order, see Each
+func (c *T) Sorted() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		snapshot := c.ToSlice()
+		slices.Sort(snapshot)
+		for _, v := range snapshot {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Any returns true if predicate returns true for any item. Iterates over a snapshot, see Each
+func (c *T) Any(item func(v string) (didMatch bool)) (anyFound bool) {
+	c.EachCancelable(func(v string) (a string_set.NextAction) {
+		if item(v) {
+			anyFound = true
+			return string_set.Break
+		}
+		return
+	})
+	return
+}
+
+// None returns true if predicate returned false for every item in the set. Iterates over a snapshot, see Each
+func (c *T) None(item func(v string) (didMatch bool)) bool {
+	return !c.Any(item)
+}
+
+// Copy returns a concurrent, shared-nothing copy of the set
+func (c *T) Copy() string_set.Interface {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return wrap(c.set.Copy())
+}
+
+// Freeze returns a read-only snapshot of the set
+func (c *T) Freeze() *string_set.Frozen {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.set.Freeze()
+}
+
+// rLockWith read-locks the callee and, if o is also a *T, o as well. Locks are always acquired in address order so
+// that two goroutines operating on the same pair of sets in opposite directions cannot deadlock. A set is never
+// read-locked twice, as a recursive read-lock deadlocks when a writer is waiting. Call the returned func to unlock
+func (c *T) rLockWith(o string_set.Immutable) (unlock func()) {
+	other, ok := o.(*T)
+	if !ok || other == c {
+		c.mu.RLock()
+		return c.mu.RUnlock
+	}
+	first, second := c, other
+	if uintptr(unsafe.Pointer(second)) < uintptr(unsafe.Pointer(first)) {
+		first, second = second, first
+	}
+	first.mu.RLock()
+	second.mu.RLock()
+	return func() {
+		second.mu.RUnlock()
+		first.mu.RUnlock()
+	}
+}
+
+// lockWith write-locks the callee and, if o is also a *T, read-locks o, in the same address order as rLockWith. If o
+// is the callee, only the write-lock is taken. Call the returned func to unlock
+func (c *T) lockWith(o string_set.Immutable) (unlock func()) {
+	other, ok := o.(*T)
+	if !ok || other == c {
+		c.mu.Lock()
+		return c.mu.Unlock
+	}
+	if uintptr(unsafe.Pointer(other)) < uintptr(unsafe.Pointer(c)) {
+		other.mu.RLock()
+		c.mu.Lock()
+	} else {
+		c.mu.Lock()
+		other.mu.RLock()
+	}
+	return func() {
+		other.mu.RUnlock()
+		c.mu.Unlock()
+	}
+}
+
+// unwrap returns the set guarded by o if o is a *T, so operations on it do not try to take its lock again.
+// The caller must already hold o's read-lock
+func unwrap(o string_set.Immutable) string_set.Immutable {
+	if other, ok := o.(*T); ok {
+		return &other.set
+	}
+	return o
+}
+
+// wrap guards the result of an operation on the underlying set
+func wrap(s string_set.Interface) *T {
+	return &T{
+		set: *s.(*string_set.T),
+	}
+}
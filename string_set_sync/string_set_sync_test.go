@@ -0,0 +1,178 @@
+package string_set_sync
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/wojnosystems/go-string-set/string_set"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestCollection_Add(t *testing.T) {
+	cases := map[string]struct {
+		input    []string
+		expected string_set.Immutable
+	}{
+		"empty": {
+			input:    []string{},
+			expected: string_set.Empty,
+		},
+		"two with duplicate": {
+			input:    []string{"a", "b", "a"},
+			expected: string_set.NewOf("a", "b"),
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			actual := NewOf(c.input...)
+			assert.True(t, c.expected.IsEqualTo(actual))
+			assert.True(t, actual.IsEqualTo(c.expected))
+		})
+	}
+}
+
+func TestCollection_Remove(t *testing.T) {
+	set := NewOf("a", "b", "c")
+	set.RemoveMany("a", "x")
+	assert.True(t, set.IsEqualTo(string_set.NewOf("b", "c")))
+	set.Remove("b")
+	assert.Equal(t, 1, set.Len())
+	assert.False(t, set.IsEmpty())
+}
+
+func TestCollection_ZeroValue(t *testing.T) {
+	var set T
+	assert.True(t, set.IsEmpty())
+	assert.False(t, set.Includes("a"))
+	set.Remove("a")
+	set.Add("a")
+	assert.True(t, string_set.NewOf("a").IsEqualTo(&set))
+
+	var other T
+	assert.Equal(t, 1, other.UnionWith(&set))
+	assert.True(t, other.IsEqualTo(&set))
+}
+
+func TestCollection_Setter(t *testing.T) {
+	a := NewOf("a", "b")
+	b := NewOf("b", "c")
+
+	union := a.Union(b)
+	assert.IsType(t, &T{}, union)
+	assert.True(t, string_set.NewOf("a", "b", "c").IsEqualTo(union))
+	assert.True(t, string_set.NewOf("a").IsEqualTo(a.Subtract(b)))
+	assert.True(t, string_set.NewOf("b").IsEqualTo(a.Intersection(b)))
+	assert.True(t, string_set.NewOf("b").IsEqualTo(a.Intersection(string_set.NewOf("b"))))
+	assert.True(t, string_set.NewOf("a", "c").IsEqualTo(a.SymmetricDifference(b)))
+	assert.IsType(t, &T{}, a.SymmetricDifference(b))
+	assert.Equal(t, 1, a.IntersectionLen(b))
+	assert.True(t, a.IsSubsetOf(union))
+	assert.True(t, union.IsSupersetOf(b))
+	assert.True(t, a.IsProperSubsetOf(union))
+	assert.False(t, a.IsDisjointFrom(b))
+	assert.True(t, a.IsEqualTo(a))
+}
+
+func TestCollection_InPlaceSetter(t *testing.T) {
+	a := NewOf("a", "b")
+	assert.Equal(t, 1, a.UnionWith(NewOf("b", "c")))
+	assert.Equal(t, 1, a.SubtractWith(string_set.NewOf("a")))
+	assert.Equal(t, 1, a.IntersectWith(NewOf("c", "d")))
+	assert.Equal(t, 2, a.SymmetricDifferenceWith(NewOf("c", "d")))
+	assert.True(t, string_set.NewOf("d").IsEqualTo(a))
+
+	// operating on itself takes the write-lock only once
+	assert.Equal(t, 0, a.UnionWith(a))
+	assert.Equal(t, 1, a.SymmetricDifferenceWith(a))
+	assert.True(t, a.IsEmpty())
+
+	old := NewOf("a", "b")
+	// a does not contain the removed "a", so only "c" is added
+	assert.Equal(t, 1, a.Apply(string_set.Diff(old, NewOf("b", "c"))))
+	assert.True(t, string_set.NewOf("c").IsEqualTo(a))
+}
+
+func TestCollection_Each(t *testing.T) {
+	set := NewOf("a", "b", "c")
+
+	// modifying the set while iterating does not deadlock and does not change what is being iterated
+	var seen []string
+	set.Each(func(v string) {
+		set.Add(v + v)
+		seen = append(seen, v)
+	})
+	sort.Strings(seen)
+	assert.Equal(t, []string{"a", "b", "c"}, seen)
+	assert.Equal(t, 6, set.Len())
+
+	count := 0
+	set.EachCancelable(func(v string) string_set.NextAction {
+		count++
+		return string_set.Break
+	})
+	assert.Equal(t, 1, count)
+}
+
+func TestCollection_AnyNone(t *testing.T) {
+	set := NewOf("a", "b")
+	assert.True(t, set.Any(func(v string) bool { return v == "b" }))
+	assert.False(t, set.None(func(v string) bool { return v == "b" }))
+	assert.True(t, set.None(func(v string) bool { return v == "c" }))
+}
+
+func TestCollection_Copy(t *testing.T) {
+	set := NewOf("a", "b")
+	actual := set.Copy()
+	actual.Add("c")
+	assert.IsType(t, &T{}, actual)
+	assert.Equal(t, 2, set.Len())
+
+	frozen := set.Freeze()
+	set.Add("c")
+	assert.Equal(t, 2, frozen.Len())
+}
+
+func TestCollection_ConcurrentSetter(t *testing.T) {
+	a := New()
+	b := New()
+	none := New()
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				v := strconv.Itoa(i*1000 + j)
+				if i%2 == 0 {
+					a.Add(v)
+					a.Union(b)
+					b.Intersection(a)
+					a.UnionWith(none)
+				} else {
+					b.Add(v)
+					b.Subtract(a)
+					a.IsEqualTo(b)
+					none.IntersectWith(a)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 800, a.Len())
+	assert.Equal(t, 800, b.Len())
+	assert.Equal(t, 1600, a.Union(b).Len())
+}
+
+func TestCollection_All(t *testing.T) {
+	set := NewOf("c", "a", "b")
+	for v := range set.All() {
+		set.Remove(v)
+	}
+	assert.True(t, set.IsEmpty())
+
+	set.AddMany("c", "a", "b")
+	assert.Equal(t, []string{"a", "b", "c"}, slices.Collect(set.Sorted()))
+}
@@ -0,0 +1,190 @@
+package string_set_trie
+
+import (
+	"sort"
+	"strings"
+)
+
+// node is a node of a radix trie. The key of a node is the concatenation of the prefixes on the path to it from the
+// root, so items that share a prefix share the nodes, and the memory, for that prefix
+type node struct {
+	// prefix is the part of the key this node adds to its parent's. Only the root has an empty prefix
+	prefix string
+	// terminal is true if the node's key is an item in the set
+	terminal bool
+	// children are sorted by the first byte of their prefix, which is unique among siblings
+	children []*node
+}
+
+// child returns the child whose prefix starts with b, or nil and the index a child starting with b should be
+// inserted at
+func (n *node) child(b byte) (i int, c *node) {
+	i = sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].prefix[0] >= b
+	})
+	if i < len(n.children) && n.children[i].prefix[0] == b {
+		c = n.children[i]
+	}
+	return
+}
+
+// insert adds v, relative to n's key, to the subtree. Returns false if it was already present
+func (n *node) insert(v string) bool {
+	if v == "" {
+		added := !n.terminal
+		n.terminal = true
+		return added
+	}
+	i, c := n.child(v[0])
+	if c == nil {
+		n.children = append(n.children, nil)
+		copy(n.children[i+1:], n.children[i:])
+		n.children[i] = &node{prefix: v, terminal: true}
+		return true
+	}
+	common := commonPrefixLen(c.prefix, v)
+	if common < len(c.prefix) {
+		split := &node{prefix: c.prefix[:common], children: []*node{c}}
+		c.prefix = c.prefix[common:]
+		n.children[i] = split
+		c = split
+	}
+	return c.insert(v[common:])
+}
+
+// remove deletes v, relative to n's key, from the subtree. Returns false if it was not present
+func (n *node) remove(v string) bool {
+	if v == "" {
+		removed := n.terminal
+		n.terminal = false
+		return removed
+	}
+	i, c := n.child(v[0])
+	if c == nil || !strings.HasPrefix(v, c.prefix) || !c.remove(v[len(c.prefix):]) {
+		return false
+	}
+	n.compact(i)
+	return true
+}
+
+// removePrefix deletes every item that starts with p, relative to n's key, from the subtree. Returns how many items
+// were removed
+func (n *node) removePrefix(p string) (removed int) {
+	if p == "" {
+		removed = n.count()
+		n.terminal = false
+		n.children = nil
+		return
+	}
+	i, c := n.child(p[0])
+	switch {
+	case c == nil:
+		return 0
+	case strings.HasPrefix(p, c.prefix):
+		removed = c.removePrefix(p[len(c.prefix):])
+	case strings.HasPrefix(c.prefix, p):
+		removed = c.count()
+		c.terminal = false
+		c.children = nil
+	}
+	if removed > 0 {
+		n.compact(i)
+	}
+	return
+}
+
+// compact removes child i if it no longer holds any items, or merges it with its only child if it is not an item
+// itself, so the trie stays as small as possible after a removal
+func (n *node) compact(i int) {
+	c := n.children[i]
+	if c.terminal {
+		return
+	}
+	switch len(c.children) {
+	case 0:
+		n.children = append(n.children[:i], n.children[i+1:]...)
+	case 1:
+		grandchild := c.children[0]
+		grandchild.prefix = c.prefix + grandchild.prefix
+		n.children[i] = grandchild
+	}
+}
+
+// find returns the node whose key is v, relative to n's key, or nil
+func (n *node) find(v string) *node {
+	for v != "" {
+		_, c := n.child(v[0])
+		if c == nil || !strings.HasPrefix(v, c.prefix) {
+			return nil
+		}
+		v = v[len(c.prefix):]
+		n = c
+	}
+	return n
+}
+
+// findPrefix returns the highest node whose key starts with p, relative to n's key, along with that key. Every item
+// that starts with p is in the returned node's subtree. Returns nil if no item starts with p
+func (n *node) findPrefix(p string) (found *node, key string) {
+	for consumed := 0; consumed < len(p); {
+		rest := p[consumed:]
+		_, c := n.child(rest[0])
+		switch {
+		case c == nil:
+			return nil, ""
+		case strings.HasPrefix(rest, c.prefix):
+			consumed += len(c.prefix)
+			n = c
+		case strings.HasPrefix(c.prefix, rest):
+			return c, p[:consumed] + c.prefix
+		default:
+			return nil, ""
+		}
+	}
+	return n, p
+}
+
+// walk calls yield with the key of each item in the subtree, in lexical order, where key is n's key. Returns false
+// if yield asked to stop
+func (n *node) walk(key string, yield func(v string) bool) bool {
+	if n.terminal && !yield(key) {
+		return false
+	}
+	for _, c := range n.children {
+		if !c.walk(key+c.prefix, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// count returns the number of items in the subtree
+func (n *node) count() (total int) {
+	if n.terminal {
+		total = 1
+	}
+	for _, c := range n.children {
+		total += c.count()
+	}
+	return
+}
+
+// clone returns a deep copy of the subtree
+func (n *node) clone() *node {
+	out := &node{
+		prefix:   n.prefix,
+		terminal: n.terminal,
+		children: make([]*node, len(n.children)),
+	}
+	for i, c := range n.children {
+		out.children[i] = c.clone()
+	}
+	return out
+}
+
+func commonPrefixLen(a, b string) (i int) {
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return
+}
@@ -0,0 +1,264 @@
+package string_set_trie
+
+import (
+	"github.com/wojnosystems/go-string-set/string_set"
+	"iter"
+	"strings"
+)
+
+// New creates a new, empty, trie-backed string set
+func New() *T {
+	return &T{
+		root: &node{},
+	}
+}
+
+// NewOf is a convenience method to create a trie-backed string set containing the items you specify
+func NewOf(items ...string) *T {
+	ret := New()
+	ret.AddMany(items...)
+	return ret
+}
+
+// T holds the underlying string_set_trie type, do not instantiate this yourself,
+// Please use New or NewOf
+//
+// T stores its items in a radix trie, so items that share a prefix, such as "tenant/app/feature" and
+// "tenant/app/other", share the memory for it, and every item under a prefix can be found without looking at the
+// rest. Each, EachCancelable, All and ToSlice visit the items in lexical (byte-wise) order. Strings are compared
+// byte-for-byte
+type T struct {
+	root *node
+	len  int
+}
+
+func (c *T) Add(v string) {
+	if c.root.insert(v) {
+		c.len++
+	}
+}
+
+func (c *T) AddMany(v ...string) {
+	for _, s := range v {
+		c.Add(s)
+	}
+}
+
+func (c *T) Remove(v string) {
+	if c.root.remove(v) {
+		c.len--
+	}
+}
+
+func (c *T) RemoveMany(v ...string) {
+	for _, s := range v {
+		c.Remove(s)
+	}
+}
+
+// UnionWith adds the items of o that the set does not already contain, as compared by string_set.CompareKey.
+// Returns the number of items added
+func (c *T) UnionWith(o string_set.Immutable) (changed int) {
+	return string_set.UnionWith(c, o)
+}
+
+// SubtractWith removes the items that o contains, as compared by string_set.CompareKey. Returns the number of items
+// removed
+func (c *T) SubtractWith(o string_set.Immutable) (changed int) {
+	return string_set.SubtractWith(c, o)
+}
+
+// IntersectWith removes the items that o does not contain, as compared by string_set.CompareKey. Returns the number of
+// items removed
+func (c *T) IntersectWith(o string_set.Immutable) (changed int) {
+	return string_set.IntersectWith(c, o)
+}
+
+// SymmetricDifferenceWith removes the items that o contains, and adds the items of o that the set did not contain,
+// as compared by string_set.CompareKey. Returns the number of items added or removed
+func (c *T) SymmetricDifferenceWith(o string_set.Immutable) (changed int) {
+	return string_set.SymmetricDifferenceWith(c, o)
+}
+
+// Apply removes the items d removed and adds the items d added, as SubtractWith and UnionWith do. Returns the number
+// of items removed or added
+func (c *T) Apply(d string_set.DiffResult) (changed int) {
+	return c.SubtractWith(d.Removed) + c.UnionWith(d.Added)
+}
+
+// RemovePrefix removes every item that starts with p and returns how many were removed
+func (c *T) RemovePrefix(p string) (removed int) {
+	removed = c.root.removePrefix(p)
+	c.len -= removed
+	return
+}
+
+func (c *T) Includes(v string) bool {
+	n := c.root.find(v)
+	return n != nil && n.terminal
+}
+
+// HasPrefix returns true if at least one item in the set starts with p
+func (c *T) HasPrefix(p string) bool {
+	n, _ := c.root.findPrefix(p)
+	return n != nil && (n.terminal || len(n.children) > 0)
+}
+
+// EachWithPrefix loops over each item in the set that starts with p, in lexical order
+func (c *T) EachWithPrefix(p string, item func(v string)) {
+	for v := range c.AllWithPrefix(p) {
+		item(v)
+	}
+}
+
+// AllWithPrefix returns an iterator over each item in the set that starts with p, in lexical order
+func (c *T) AllWithPrefix(p string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		if n, key := c.root.findPrefix(p); n != nil {
+			n.walk(key, yield)
+		}
+	}
+}
+
+// LongestPrefixOf returns the longest item in the set that s starts with, and true. If no item is a prefix of s,
+// returns "" and false
+func (c *T) LongestPrefixOf(s string) (prefix string, ok bool) {
+	n, consumed := c.root, 0
+	if n.terminal {
+		ok = true
+	}
+	for consumed < len(s) {
+		_, child := n.child(s[consumed])
+		if child == nil || !strings.HasPrefix(s[consumed:], child.prefix) {
+			break
+		}
+		consumed += len(child.prefix)
+		n = child
+		if n.terminal {
+			prefix, ok = s[:consumed], true
+		}
+	}
+	return
+}
+
+func (c *T) IsEmpty() bool {
+	return c.len == 0
+}
+
+func (c *T) Len() int {
+	return c.len
+}
+
+func (c *T) IsEqualTo(o string_set.Immutable) bool {
+	return string_set.IsEqual(c, o)
+}
+
+func (c *T) Union(o string_set.Immutable) (out string_set.Interface) {
+	out = c.Copy()
+	string_set.UnionInto(out, c, o)
+	return
+}
+
+func (c *T) Subtract(o string_set.Immutable) (out string_set.Interface) {
+	out = New()
+	string_set.SubtractInto(out, c, o)
+	return
+}
+
+func (c *T) Intersection(o string_set.Immutable) (out string_set.Interface) {
+	out = New()
+	string_set.IntersectionInto(out, c, o)
+	return
+}
+
+// SymmetricDifference returns a new set containing the items of either the callee or the parameter that are
+// not in both, as compared by string_set.CompareKey
+func (c *T) SymmetricDifference(o string_set.Immutable) (out string_set.Interface) {
+	out = New()
+	string_set.SymmetricDifferenceInto(out, c, o)
+	return
+}
+
+// IsSubsetOf returns true if o contains every item of the set, as compared by string_set.CompareKey
+func (c *T) IsSubsetOf(o string_set.Immutable) bool {
+	return string_set.IsSubset(c, o)
+}
+
+// IsProperSubsetOf returns true if o contains every item of the set and has items the set does not, as compared
+// by string_set.CompareKey
+func (c *T) IsProperSubsetOf(o string_set.Immutable) bool {
+	return string_set.IsProperSubset(c, o)
+}
+
+// IsSupersetOf returns true if the set contains every item of o, as compared by string_set.CompareKey
+func (c *T) IsSupersetOf(o string_set.Immutable) bool {
+	return string_set.IsSubset(o, c)
+}
+
+// IsDisjointFrom returns true if the set and o have no items in common, as compared by string_set.CompareKey
+func (c *T) IsDisjointFrom(o string_set.Immutable) bool {
+	return string_set.IsDisjoint(c, o)
+}
+
+// IntersectionLen returns the number of items Intersection would return, without creating the intersection
+func (c *T) IntersectionLen(o string_set.Immutable) int {
+	return string_set.IntersectionLen(c, o)
+}
+
+// ToSlice returns the items of the set in lexical order
+func (c *T) ToSlice() (out []string) {
+	out = make([]string, 0, c.len)
+	for v := range c.All() {
+		out = append(out, v)
+	}
+	return
+}
+
+// Each loops over each string in the set in lexical order
+func (c *T) Each(item func(v string)) {
+	for v := range c.All() {
+		item(v)
+	}
+}
+
+// EachCancelable is just like Each, but you can stop the iteration by returning
+// string_set.Break instead of string_set.Continue
+func (c *T) EachCancelable(item func(v string) (next string_set.NextAction)) {
+	for v := range c.All() {
+		if item(v) == string_set.Break {
+			break
+		}
+	}
+}
+
+// All returns an iterator over the items in the set in lexical order
+func (c *T) All() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		c.root.walk("", yield)
+	}
+}
+
+// Any returns true if predicate returns true for any item. Short-circuits and stops iteration when didMatch
+// returns true
+func (c *T) Any(item func(v string) (didMatch bool)) bool {
+	for v := range c.All() {
+		if item(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// None return true if predicate returned false for every item in the set. If predicate returns true, short-circuit
+// and return false from this method, indicating that at least 1 item matched
+func (c *T) None(item func(v string) (didMatch bool)) bool {
+	return !c.Any(item)
+}
+
+// Copy returns a shared-nothing copy of the set
+func (c *T) Copy() string_set.Interface {
+	return &T{
+		root: c.root.clone(),
+		len:  c.len,
+	}
+}
@@ -0,0 +1,206 @@
+package string_set_trie
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/wojnosystems/go-string-set/string_set"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCollection_AddRemove(t *testing.T) {
+	set := New()
+	expected := string_set.New()
+	random := rand.New(rand.NewSource(1))
+	parts := []string{"", "a", "ab", "b", "/", "tenant", "app"}
+	for i := 0; i < 5000; i++ {
+		v := parts[random.Intn(len(parts))] + parts[random.Intn(len(parts))] + parts[random.Intn(len(parts))]
+		switch random.Intn(5) {
+		case 0:
+			set.Remove(v)
+			expected.Remove(v)
+		case 1:
+			p := v[:random.Intn(len(v)+1)]
+			removed := 0
+			for _, item := range expected.ToSlice() {
+				if strings.HasPrefix(item, p) {
+					expected.Remove(item)
+					removed++
+				}
+			}
+			assert.Equal(t, removed, set.RemovePrefix(p))
+		default:
+			set.Add(v)
+			expected.Add(v)
+		}
+		assert.Equal(t, expected.Len(), set.Len())
+	}
+	assert.True(t, set.IsEqualTo(expected))
+	assert.True(t, expected.IsEqualTo(set))
+	assert.True(t, slices.IsSorted(set.ToSlice()))
+	assertCompact(t, set.root, true)
+}
+
+// assertCompact checks that every node other than the root holds an item or branches
+func assertCompact(t *testing.T, n *node, isRoot bool) {
+	if !isRoot {
+		assert.NotEmpty(t, n.prefix)
+		assert.True(t, n.terminal || len(n.children) > 1, "node %q should have been compacted", n.prefix)
+	}
+	for _, c := range n.children {
+		assertCompact(t, c, false)
+	}
+}
+
+func TestCollection_Includes(t *testing.T) {
+	set := NewOf("tenant/app", "tenant/app/feature", "")
+	assert.True(t, set.Includes("tenant/app"))
+	assert.True(t, set.Includes("tenant/app/feature"))
+	assert.True(t, set.Includes(""))
+	assert.False(t, set.Includes("tenant"))
+	assert.False(t, set.Includes("tenant/app/"))
+	assert.False(t, set.Includes("tenant/app/feature/x"))
+}
+
+func TestCollection_Prefix(t *testing.T) {
+	set := NewOf("acme/billing/invoices", "acme/billing/refunds", "acme/search", "globex/search")
+	cases := map[string]struct {
+		prefix    string
+		hasPrefix bool
+		expected  []string
+	}{
+		"everything": {
+			prefix:    "",
+			hasPrefix: true,
+			expected:  []string{"acme/billing/invoices", "acme/billing/refunds", "acme/search", "globex/search"},
+		},
+		"ends on a node boundary": {
+			prefix:    "acme/billing/",
+			hasPrefix: true,
+			expected:  []string{"acme/billing/invoices", "acme/billing/refunds"},
+		},
+		"ends inside a node": {
+			prefix:    "acme/bil",
+			hasPrefix: true,
+			expected:  []string{"acme/billing/invoices", "acme/billing/refunds"},
+		},
+		"whole item": {
+			prefix:    "acme/search",
+			hasPrefix: true,
+			expected:  []string{"acme/search"},
+		},
+		"missing": {
+			prefix: "acme/x",
+		},
+		"longer than any item": {
+			prefix: "acme/search/more",
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			assert.Equal(t, c.hasPrefix, set.HasPrefix(c.prefix))
+			var actual []string
+			set.EachWithPrefix(c.prefix, func(v string) {
+				actual = append(actual, v)
+			})
+			assert.Equal(t, c.expected, actual)
+		})
+	}
+}
+
+func TestCollection_LongestPrefixOf(t *testing.T) {
+	set := NewOf("/", "/api", "/api/v1", "/static")
+	cases := map[string]struct {
+		input    string
+		expected string
+		found    bool
+	}{
+		"exact": {
+			input:    "/api",
+			expected: "/api",
+			found:    true,
+		},
+		"longest": {
+			input:    "/api/v1/users",
+			expected: "/api/v1",
+			found:    true,
+		},
+		"shorter item": {
+			input:    "/api/v2",
+			expected: "/api",
+			found:    true,
+		},
+		"root only": {
+			input:    "/other",
+			expected: "/",
+			found:    true,
+		},
+		"none": {
+			input: "api",
+		},
+	}
+
+	for caseName, c := range cases {
+		t.Run(caseName, func(t *testing.T) {
+			actual, found := set.LongestPrefixOf(c.input)
+			assert.Equal(t, c.expected, actual)
+			assert.Equal(t, c.found, found)
+		})
+	}
+
+	withEmpty := NewOf("")
+	actual, found := withEmpty.LongestPrefixOf("x")
+	assert.Equal(t, "", actual)
+	assert.True(t, found)
+}
+
+func TestCollection_RemovePrefix(t *testing.T) {
+	set := NewOf("acme/a", "acme/b", "acme", "acmex", "globex")
+	assert.Equal(t, 2, set.RemovePrefix("acme/"))
+	assert.Equal(t, []string{"acme", "acmex", "globex"}, set.ToSlice())
+	assert.Equal(t, 0, set.RemovePrefix("missing"))
+	assert.Equal(t, 2, set.RemovePrefix("acm"))
+	assert.Equal(t, 1, set.RemovePrefix(""))
+	assert.True(t, set.IsEmpty())
+}
+
+func TestCollection_Setter(t *testing.T) {
+	a := NewOf("a/1", "a/2", "b")
+	b := string_set.NewOf("a/2", "c")
+
+	assert.Equal(t, []string{"a/1", "a/2", "b", "c"}, a.Union(b).ToSlice())
+	assert.Equal(t, []string{"a/1", "b"}, a.Subtract(b).ToSlice())
+	assert.Equal(t, []string{"a/2"}, a.Intersection(b).ToSlice())
+	assert.Equal(t, []string{"a/1", "b", "c"}, a.SymmetricDifference(b).ToSlice())
+	assert.Equal(t, 1, a.IntersectionLen(b))
+	assert.True(t, a.IsSupersetOf(NewOf("a/1", "b")))
+	assert.True(t, a.IsDisjointFrom(NewOf("a", "a/3")))
+
+	copied := a.Copy()
+	copied.Remove("a/1")
+	assert.True(t, a.Includes("a/1"))
+	assert.True(t, a.Any(func(v string) bool { return v == "b" }))
+	assert.True(t, a.None(func(v string) bool { return v == "c" }))
+
+	var each []string
+	a.EachCancelable(func(v string) string_set.NextAction {
+		each = append(each, v)
+		return string_set.Break
+	})
+	assert.Equal(t, []string{"a/1"}, each)
+}
+
+func TestCollection_InPlaceSetter(t *testing.T) {
+	set := NewOf("a/1", "a/2", "b")
+	assert.Equal(t, 1, set.UnionWith(string_set.NewOf("a/2", "c")))
+	assert.Equal(t, 1, set.SubtractWith(NewOf("a", "a/1")))
+	assert.Equal(t, 1, set.IntersectWith(NewOf("a/2", "b")))
+	assert.Equal(t, []string{"a/2", "b"}, set.ToSlice())
+	assert.Equal(t, 2, set.SymmetricDifferenceWith(NewOf("b", "a/3")))
+	assert.Equal(t, []string{"a/2", "a/3"}, set.ToSlice())
+	assert.Equal(t, 2, set.Apply(string_set.Diff(NewOf("a/2"), NewOf("b"))))
+	assert.Equal(t, []string{"a/3", "b"}, set.ToSlice())
+	assert.True(t, set.HasPrefix("a/"))
+}